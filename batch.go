@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/resolver"
+)
+
+const (
+	// maxLookupBatch caps the number of addresses per POST /lookup request
+	maxLookupBatch = 100
+	// batchConcurrency bounds the lookups a single batch runs at once
+	batchConcurrency = 16
+)
+
+// handleBatchLookup geolocates a JSON array of IP addresses and host names.
+// Results are returned in request order; failures are reported per entry in
+// message.
+func (a *App) handleBatchLookup(c *fiber.Ctx) error {
+	work, err := requestWork(c)
+	if err != nil {
+		return writeError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	var queries []string
+	if err := c.App().Config().JSONDecoder(c.Body(), &queries); err != nil {
+		return render(c.Status(fiber.StatusBadRequest), Response{
+			Message: "invalid request body: expected a JSON array of IP addresses",
+		})
+	}
+	if len(queries) > maxLookupBatch {
+		return render(c.Status(fiber.StatusBadRequest), Response{
+			Message: fmt.Sprintf("too many addresses: at most %d per request", maxLookupBatch),
+		})
+	}
+
+	var deviceBrowser DeviceInfo
+	if work.device {
+		deviceBrowser = a.getDeviceInfo(c)
+	}
+
+	results := make([]Response, len(queries))
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, batchConcurrency)
+	)
+	for i, query := range queries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(result *Response, query string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			*result = a.lookupQuery(c.UserContext(), query, work)
+			result.DeviceBrowser = deviceBrowser
+		}(&results[i], query)
+	}
+	wg.Wait()
+
+	return render(c, results)
+}
+
+// lookupQuery geolocates one batch entry, an IP address in any accepted
+// notation or a host name. Failures carry the status GET /lookup/:ip would
+// have sent for them.
+func (a *App) lookupQuery(ctx context.Context, query string, work lookupWork) Response {
+	ip, err := parseIP(query)
+	if err != nil {
+		if !resolver.IsHostname(query) {
+			return Response{Message: err.Error(), Status: fiber.StatusBadRequest}
+		}
+
+		addresses, err := a.lookupHost(ctx, query, work)
+		if errors.Is(err, resolver.ErrNotFound) {
+			return Response{Message: err.Error(), Host: query, Status: fiber.StatusNotFound}
+		}
+		if err != nil {
+			return Response{Message: "DNS resolution failed: " + err.Error(), Host: query, Status: fiber.StatusBadGateway}
+		}
+		return Response{Host: query, Addresses: addresses}
+	}
+
+	result := a.lookup(ctx, ip, work)
+	if work.providers() && len(result.Locations) == 0 {
+		return Response{Message: "Failed to lookup IP address", Ip: ip}
+	}
+	return locatedResponse(ip, result)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// cache is a concurrency-safe LRU cache of responses that expire after a
+// TTL. A nil cache holds nothing, and the caller's own address, the empty
+// query, is never cached since it depends on the route to the service.
+type cache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	query    string
+	response *Response
+	expires  time.Time
+}
+
+func newCache(size int, ttl time.Duration) *cache {
+	return &cache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *cache) get(query string) (*Response, bool) {
+	if c == nil || query == "" {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[query]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, query)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.response, true
+}
+
+func (c *cache) put(query string, response *Response) {
+	if c == nil || query == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.ttl)
+	if elem, ok := c.entries[query]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.response, entry.expires = response, expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[query] = c.order.PushFront(&cacheEntry{query: query, response: response, expires: expires})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).query)
+	}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheTTL(t *testing.T) {
+	c := newCache(10, 50*time.Millisecond)
+	c.put("8.8.8.8", &Response{Ip: "8.8.8.8"})
+
+	if response, ok := c.get("8.8.8.8"); !ok || response.Ip != "8.8.8.8" {
+		t.Fatalf("get() = %v, %v before the TTL", response, ok)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if _, ok := c.get("8.8.8.8"); ok {
+		t.Error("get() returned an expired response")
+	}
+	if c.order.Len() != 0 || len(c.entries) != 0 {
+		t.Error("the expired response was not removed")
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := newCache(2, time.Minute)
+	c.put("a", &Response{Ip: "a"})
+	c.put("b", &Response{Ip: "b"})
+	// a becomes the most recently used, leaving b to be evicted
+	c.get("a")
+	c.put("c", &Response{Ip: "c"})
+
+	for query, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := c.get(query); ok != want {
+			t.Errorf("get(%q) found = %v, want %v", query, ok, want)
+		}
+	}
+
+	// Putting a query again refreshes it rather than adding an entry
+	c.put("a", &Response{Ip: "a2"})
+	if response, _ := c.get("a"); response.Ip != "a2" || c.order.Len() != 2 {
+		t.Errorf("get(a) = %v with %d entries", response, c.order.Len())
+	}
+}
+
+func TestCacheSkipsCaller(t *testing.T) {
+	c := newCache(2, time.Minute)
+	c.put("", &Response{Ip: "192.0.2.1"})
+	if _, ok := c.get(""); ok {
+		t.Error("the caller's own address was cached")
+	}
+
+	var disabled *cache
+	disabled.put("a", &Response{})
+	if _, ok := disabled.get("a"); ok {
+		t.Error("a nil cache returned a response")
+	}
+}
@@ -0,0 +1,378 @@
+// Package client is the Go SDK of the ip2location service. NewREST and
+// NewGRPC return a Client that talks to the /v2 API over HTTP or gRPC with
+// the same typed results, and NewFake one that answers from memory for
+// tests.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Response is the result of one lookup, as the v2 gRPC service returns it.
+// Responses may be shared with the client's cache and must be treated as
+// read-only.
+type Response = pbv2.LookupResponse
+
+// Client geolocates IP addresses and host names. An empty query
+// geolocates the caller. Implementations are safe for concurrent use.
+type Client interface {
+	// Lookup geolocates one address or host name. Failed lookups return
+	// an *Error.
+	Lookup(ctx context.Context, query string) (*Response, error)
+	// LookupBatch geolocates many queries, returning one Result per query
+	// in order. The error is only set when ctx ends first.
+	LookupBatch(ctx context.Context, queries []string) ([]Result, error)
+	// Stream geolocates queries as they arrive and sends a Result for
+	// each, in the order they complete. The channel is closed once
+	// queries is closed and drained, or ctx ends.
+	Stream(ctx context.Context, queries <-chan string) <-chan Result
+	// Close releases the connections of the client
+	Close() error
+}
+
+// Result is the outcome of one query of a batch or stream
+type Result struct {
+	Query    string
+	Response *Response
+	Err      error
+}
+
+// Error is a lookup the service failed, such as an invalid address or a
+// host that does not resolve
+type Error struct {
+	// Code is the v2 error code, e.g. invalid_request or lookup_failed
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("ip2location: %s: %s", e.Code, e.Message)
+}
+
+// ErrUnavailable is wrapped by errors of the REST transport that are worth
+// retrying: connection failures and 502, 503 and 504 responses
+var ErrUnavailable = errors.New("ip2location: service unavailable")
+
+// retryable reports whether a failed call may succeed when repeated
+func retryable(err error) bool {
+	return errors.Is(err, ErrUnavailable) || status.Code(err) == codes.Unavailable
+}
+
+// Defaults of the options
+const (
+	DefaultMaxAttempts = 3
+	DefaultBackoff     = 100 * time.Millisecond
+	DefaultTimeout     = 10 * time.Second
+	DefaultConcurrency = 8
+	DefaultPoolSize    = 4
+
+	// maxBackoff caps the delay between attempts
+	maxBackoff = 5 * time.Second
+)
+
+// Option configures a client
+type Option func(*options)
+
+type options struct {
+	fields      []string
+	userAgent   string
+	maxAttempts int
+	backoff     time.Duration
+	timeout     time.Duration
+	concurrency int
+	poolSize    int
+	cacheSize   int
+	cacheTTL    time.Duration
+	httpClient  *http.Client
+	dialOptions []grpc.DialOption
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultBackoff,
+		timeout:     DefaultTimeout,
+		concurrency: DefaultConcurrency,
+		poolSize:    DefaultPoolSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithFields limits responses, and the lookups the service runs, to the
+// given v2 field paths, e.g. providers.maxmind.country or device.os
+func WithFields(paths ...string) Option {
+	return func(o *options) { o.fields = paths }
+}
+
+// WithUserAgent sets the user agent the service reports in device
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) { o.userAgent = userAgent }
+}
+
+// WithRetry sets how many times a call is attempted when the service is
+// unavailable, and the delay before the first retry, which doubles with
+// every attempt. One attempt disables retries.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.maxAttempts = maxAttempts
+		o.backoff = backoff
+	}
+}
+
+// WithTimeout bounds calls whose context has no deadline, retries
+// included. Zero leaves them unbounded.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithConcurrency bounds the requests a batch or stream has in flight
+func WithConcurrency(n int) Option {
+	return func(o *options) { o.concurrency = n }
+}
+
+// WithPoolSize sets the number of gRPC connections calls are spread over,
+// or the idle HTTP connections kept open
+func WithPoolSize(n int) Option {
+	return func(o *options) { o.poolSize = n }
+}
+
+// WithCache keeps up to size successful responses for ttl
+func WithCache(size int, ttl time.Duration) Option {
+	return func(o *options) {
+		o.cacheSize = size
+		o.cacheTTL = ttl
+	}
+}
+
+// transport makes single attempts at lookups; client adds retries,
+// caching and batching on top
+type transport interface {
+	lookup(ctx context.Context, query string) (*Response, error)
+	// lookupBatch looks up at most batchSize queries at once
+	lookupBatch(ctx context.Context, queries []string) ([]*Response, error)
+	batchSize() int
+	close() error
+}
+
+// client implements Client over a transport
+type client struct {
+	transport transport
+	options   options
+	cache     *cache
+}
+
+func newClient(t transport, o options) *client {
+	c := &client{transport: t, options: o}
+	if o.cacheSize > 0 && o.cacheTTL > 0 {
+		c.cache = newCache(o.cacheSize, o.cacheTTL)
+	}
+	return c
+}
+
+// withTimeout applies the default timeout to contexts without a deadline
+func (c *client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || c.options.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.options.timeout)
+}
+
+// retry calls fn until it succeeds, fails for good or runs out of
+// attempts, backing off exponentially with jitter. It gives up early
+// when the next attempt would start after the deadline.
+func (c *client) retry(ctx context.Context, fn func(context.Context) error) error {
+	delay := c.options.backoff
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !retryable(err) || attempt >= c.options.maxAttempts {
+			return err
+		}
+
+		wait := delay/2 + time.Duration(rand.Int63n(int64(delay)+1))/2
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return err
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+		if delay *= 2; delay > maxBackoff {
+			delay = maxBackoff
+		}
+	}
+}
+
+// Lookup implements Client
+func (c *client) Lookup(ctx context.Context, query string) (*Response, error) {
+	if response, ok := c.cache.get(query); ok {
+		return response, nil
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	var response *Response
+	err := c.retry(ctx, func(ctx context.Context) (err error) {
+		response, err = c.transport.lookup(ctx, query)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := responseError(response); err != nil {
+		return nil, err
+	}
+
+	c.cache.put(query, response)
+	return response, nil
+}
+
+// LookupBatch implements Client. Cached queries are answered directly and
+// the rest are sent in batches of the transport's size.
+func (c *client) LookupBatch(ctx context.Context, queries []string) ([]Result, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	results := make([]Result, len(queries))
+	var missing []int
+	for i, query := range queries {
+		results[i].Query = query
+		if response, ok := c.cache.get(query); ok {
+			results[i].Response = response
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, concurrency(c.options.concurrency))
+		size = c.transport.batchSize()
+	)
+	for start := 0; start < len(missing); start += size {
+		end := start + size
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(indexes []int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			c.lookupChunk(ctx, results, indexes)
+		}(missing[start:end])
+	}
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+// lookupChunk looks up the results at indexes in one request
+func (c *client) lookupChunk(ctx context.Context, results []Result, indexes []int) {
+	queries := make([]string, len(indexes))
+	for i, index := range indexes {
+		queries[i] = results[index].Query
+	}
+
+	var responses []*Response
+	err := c.retry(ctx, func(ctx context.Context) (err error) {
+		responses, err = c.transport.lookupBatch(ctx, queries)
+		return err
+	})
+	if err == nil && len(responses) != len(queries) {
+		err = fmt.Errorf("ip2location: %d results for %d queries", len(responses), len(queries))
+	}
+
+	for i, index := range indexes {
+		result := &results[index]
+		if err != nil {
+			result.Err = err
+			continue
+		}
+		if result.Err = responseError(responses[i]); result.Err == nil {
+			result.Response = responses[i]
+			c.cache.put(result.Query, responses[i])
+		}
+	}
+}
+
+// Stream implements Client
+func (c *client) Stream(ctx context.Context, queries <-chan string) <-chan Result {
+	return stream(ctx, queries, c.options.concurrency, c.Lookup)
+}
+
+// Close implements Client
+func (c *client) Close() error {
+	return c.transport.close()
+}
+
+// stream runs lookup over queries with up to n lookups at once
+func stream(ctx context.Context, queries <-chan string, n int, lookup func(context.Context, string) (*Response, error)) <-chan Result {
+	results := make(chan Result)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency(n); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var query string
+				select {
+				case <-ctx.Done():
+					return
+				case q, ok := <-queries:
+					if !ok {
+						return
+					}
+					query = q
+				}
+
+				response, err := lookup(ctx, query)
+				select {
+				case results <- Result{Query: query, Response: response, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+func concurrency(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// responseError returns the error a response carries
+func responseError(response *Response) error {
+	if e := response.GetError(); e != nil {
+		return &Error{Code: e.Code, Message: e.Message}
+	}
+	return nil
+}
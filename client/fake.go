@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Fake is an in-memory Client for tests. It answers the queries given to
+// Set and SetError; other queries fail with a lookup_failed *Error, as
+// the service does for addresses it cannot locate.
+type Fake struct {
+	mu        sync.Mutex
+	responses map[string]*Response
+	errs      map[string]error
+	queries   []string
+}
+
+var _ Client = (*Fake)(nil)
+
+// NewFake returns a Fake that knows no queries
+func NewFake() *Fake {
+	return &Fake{
+		responses: make(map[string]*Response),
+		errs:      make(map[string]error),
+	}
+}
+
+// Set makes lookups of query return a copy of response
+func (f *Fake) Set(query string, response *Response) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[query] = proto.Clone(response).(*Response)
+	delete(f.errs, query)
+}
+
+// SetError makes lookups of query fail with err
+func (f *Fake) SetError(query string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errs[query] = err
+	delete(f.responses, query)
+}
+
+// Queries returns every query looked up so far, in order
+func (f *Fake) Queries() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.queries...)
+}
+
+// Lookup implements Client
+func (f *Fake) Lookup(ctx context.Context, query string) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, query)
+
+	if err, ok := f.errs[query]; ok {
+		return nil, err
+	}
+	if response, ok := f.responses[query]; ok {
+		return proto.Clone(response).(*Response), nil
+	}
+	return nil, &Error{Code: "lookup_failed", Message: "Failed to lookup IP address"}
+}
+
+// LookupBatch implements Client
+func (f *Fake) LookupBatch(ctx context.Context, queries []string) ([]Result, error) {
+	results := make([]Result, len(queries))
+	for i, query := range queries {
+		response, err := f.Lookup(ctx, query)
+		results[i] = Result{Query: query, Response: response, Err: err}
+	}
+	return results, ctx.Err()
+}
+
+// Stream implements Client
+func (f *Fake) Stream(ctx context.Context, queries <-chan string) <-chan Result {
+	return stream(ctx, queries, 1, f.Lookup)
+}
+
+// Close implements Client
+func (f *Fake) Close() error {
+	return nil
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestFake(t *testing.T) {
+	f := NewFake()
+	f.Set("8.8.8.8", &Response{Ip: "8.8.8.8", Host: "dns.google"})
+	outage := errors.New("outage")
+	f.SetError("1.1.1.1", outage)
+	ctx := context.Background()
+
+	response, err := f.Lookup(ctx, "8.8.8.8")
+	if err != nil || response.Host != "dns.google" {
+		t.Fatalf("Lookup() = %v, %v", response, err)
+	}
+	// Responses are copies, so callers cannot change the Fake's
+	response.Host = "changed"
+	if again, _ := f.Lookup(ctx, "8.8.8.8"); again.Host != "dns.google" {
+		t.Error("a caller changed a stored response")
+	}
+
+	if _, err := f.Lookup(ctx, "1.1.1.1"); err != outage {
+		t.Errorf("Lookup(1.1.1.1) error = %v, want %v", err, outage)
+	}
+	var lookupErr *Error
+	if _, err := f.Lookup(ctx, "192.0.2.1"); !errors.As(err, &lookupErr) || lookupErr.Code != "lookup_failed" {
+		t.Errorf("Lookup(192.0.2.1) error = %v, want lookup_failed", err)
+	}
+
+	// Set and SetError replace each other
+	f.Set("1.1.1.1", &Response{Ip: "1.1.1.1"})
+	if _, err := f.Lookup(ctx, "1.1.1.1"); err != nil {
+		t.Errorf("Lookup(1.1.1.1) error = %v after Set", err)
+	}
+
+	want := []string{"8.8.8.8", "8.8.8.8", "1.1.1.1", "192.0.2.1", "1.1.1.1"}
+	if got := f.Queries(); !slices.Equal(got, want) {
+		t.Errorf("Queries() = %v, want %v", got, want)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := f.Lookup(canceled, "8.8.8.8"); err != context.Canceled {
+		t.Errorf("Lookup() error = %v with a canceled context", err)
+	}
+}
+
+func TestFakeBatchAndStream(t *testing.T) {
+	f := NewFake()
+	f.Set("8.8.8.8", &Response{Ip: "8.8.8.8"})
+	f.Set("8.8.4.4", &Response{Ip: "8.8.4.4"})
+	ctx := context.Background()
+
+	results, err := f.LookupBatch(ctx, []string{"8.8.8.8", "192.0.2.1", "8.8.4.4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Response.Ip != "8.8.8.8" || results[1].Err == nil || results[2].Response.Ip != "8.8.4.4" {
+		t.Errorf("LookupBatch() = %+v", results)
+	}
+
+	queries := make(chan string, 3)
+	queries <- "8.8.8.8"
+	queries <- "8.8.4.4"
+	queries <- "192.0.2.1"
+	close(queries)
+	var got []string
+	failed := 0
+	for result := range f.Stream(ctx, queries) {
+		got = append(got, result.Query)
+		if result.Err != nil {
+			failed++
+		}
+	}
+	sort.Strings(got)
+	if !slices.Equal(got, []string{"192.0.2.1", "8.8.4.4", "8.8.8.8"}) || failed != 1 {
+		t.Errorf("Stream() sent %v with %d failures", got, failed)
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync/atomic"
+
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// WithDialOptions sets the options of the gRPC connections. Without any
+// the connections are plaintext, as the service serves them; options
+// replace that default, so pass credentials along with them.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = opts }
+}
+
+// NewGRPC returns a Client for the ip2location.v2 gRPC service at target,
+// such as localhost:50051. Calls are spread over WithPoolSize connections
+// and carry the deadline of their context.
+func NewGRPC(target string, opts ...Option) (Client, error) {
+	o := newOptions(opts)
+	dialOptions := o.dialOptions
+	if len(dialOptions) == 0 {
+		dialOptions = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	t := &grpcTransport{options: o}
+	for i := 0; i < concurrency(o.poolSize); i++ {
+		conn, err := grpc.Dial(target, dialOptions...)
+		if err != nil {
+			t.close()
+			return nil, err
+		}
+		t.conns = append(t.conns, conn)
+		t.clients = append(t.clients, pbv2.NewIP2LocationServiceClient(conn))
+	}
+	return newClient(t, o), nil
+}
+
+type grpcTransport struct {
+	conns   []*grpc.ClientConn
+	clients []pbv2.IP2LocationServiceClient
+	next    atomic.Uint32
+	options options
+}
+
+func (t *grpcTransport) lookup(ctx context.Context, query string) (*Response, error) {
+	req := &pbv2.LookupRequest{UserAgent: t.options.userAgent}
+	if _, err := netip.ParseAddr(query); err == nil {
+		req.Ip = query
+	} else {
+		req.Host = query
+	}
+	if len(t.options.fields) > 0 {
+		req.FieldMask = &fieldmaskpb.FieldMask{Paths: t.options.fields}
+	}
+
+	client := t.clients[int(t.next.Add(1))%len(t.clients)]
+	return client.LookupIP(ctx, req)
+}
+
+// lookupBatch makes one call per query since the service has no batch
+// method; the client runs the calls concurrently
+func (t *grpcTransport) lookupBatch(ctx context.Context, queries []string) ([]*Response, error) {
+	responses := make([]*Response, len(queries))
+	for i, query := range queries {
+		response, err := t.lookup(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = response
+	}
+	return responses, nil
+}
+
+func (t *grpcTransport) batchSize() int {
+	return 1
+}
+
+func (t *grpcTransport) close() error {
+	var errs []error
+	for _, conn := range t.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
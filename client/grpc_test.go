@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// lookupServer is a v2 service whose LookupIP fails with unavailable
+// for the first failures calls, then answers with the ip or host asked
+type lookupServer struct {
+	pbv2.UnimplementedIP2LocationServiceServer
+
+	failures int32
+	// failWith replaces the unavailable status when set
+	failWith error
+	// delay holds every call back, or until its deadline
+	delay time.Duration
+
+	calls     atomic.Int32
+	mu        sync.Mutex
+	requests  []*pbv2.LookupRequest
+	deadlines []bool
+}
+
+func (s *lookupServer) LookupIP(ctx context.Context, req *pbv2.LookupRequest) (*pbv2.LookupResponse, error) {
+	call := s.calls.Add(1)
+	_, hasDeadline := ctx.Deadline()
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.deadlines = append(s.deadlines, hasDeadline)
+	s.mu.Unlock()
+
+	if s.delay > 0 {
+		select {
+		case <-time.After(s.delay):
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if call <= s.failures {
+		if s.failWith != nil {
+			return nil, s.failWith
+		}
+		return nil, status.Error(codes.Unavailable, "overloaded")
+	}
+	if req.Ip == "192.0.2.255" {
+		return &pbv2.LookupResponse{Ip: req.Ip, Error: &pbv2.Error{Code: "lookup_failed", Message: "Failed to lookup IP address"}}, nil
+	}
+	return &pbv2.LookupResponse{Ip: req.Ip, Host: req.Host}, nil
+}
+
+// newGRPCTest serves s over an in-memory listener and returns a client of
+// it
+func newGRPCTest(t *testing.T, s *lookupServer, opts ...Option) Client {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pbv2.RegisterIP2LocationServiceServer(server, s)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	dial := WithDialOptions(
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	c, err := NewGRPC("passthrough:///bufconn", append([]Option{dial}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestGRPCLookup(t *testing.T) {
+	s := &lookupServer{}
+	c := newGRPCTest(t, s, WithFields("providers.maxmind.country"), WithUserAgent("test/1.0"), WithPoolSize(2))
+
+	for _, query := range []string{"8.8.8.8", "2001:db8::1", "example.com"} {
+		response, err := c.Lookup(context.Background(), query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.Ip+response.Host != query {
+			t.Errorf("Lookup(%s) = %v", query, response)
+		}
+	}
+
+	// Addresses go in ip and everything else in host
+	want := []struct{ ip, host string }{{"8.8.8.8", ""}, {"2001:db8::1", ""}, {"", "example.com"}}
+	for i, req := range s.requests {
+		if req.Ip != want[i].ip || req.Host != want[i].host {
+			t.Errorf("request %d asked for ip %q and host %q", i, req.Ip, req.Host)
+		}
+		if req.UserAgent != "test/1.0" || len(req.GetFieldMask().GetPaths()) != 1 {
+			t.Errorf("request %d = %v", i, req)
+		}
+		// DefaultTimeout gives every call a deadline
+		if !s.deadlines[i] {
+			t.Errorf("request %d had no deadline", i)
+		}
+	}
+
+	_, err := c.Lookup(context.Background(), "192.0.2.255")
+	var lookupErr *Error
+	if !errors.As(err, &lookupErr) || lookupErr.Code != "lookup_failed" {
+		t.Errorf("Lookup() error = %v, want lookup_failed", err)
+	}
+}
+
+func TestGRPCRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *lookupServer
+		attempts int
+		calls    int32
+		code     codes.Code
+		// minimum is the least time the backoff must have taken
+		minimum time.Duration
+	}{
+		{
+			// Waits of at least 10ms then 20ms, half the doubling delay
+			name:   "recovers",
+			server: &lookupServer{failures: 2}, attempts: 3,
+			calls: 3, code: codes.OK, minimum: 30 * time.Millisecond,
+		},
+		{
+			name:   "gives up",
+			server: &lookupServer{failures: 5}, attempts: 3,
+			calls: 3, code: codes.Unavailable,
+		},
+		{
+			name:   "one attempt",
+			server: &lookupServer{failures: 1}, attempts: 1,
+			calls: 1, code: codes.Unavailable,
+		},
+		{
+			name:   "not retryable",
+			server: &lookupServer{failures: 1, failWith: status.Error(codes.InvalidArgument, "bad")}, attempts: 3,
+			calls: 1, code: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newGRPCTest(t, tt.server, WithRetry(tt.attempts, 20*time.Millisecond))
+
+			start := time.Now()
+			_, err := c.Lookup(context.Background(), "8.8.8.8")
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("Lookup() error = %v, want %v", err, tt.code)
+			}
+			if calls := tt.server.calls.Load(); calls != tt.calls {
+				t.Errorf("%d calls, want %d", calls, tt.calls)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minimum {
+				t.Errorf("Lookup() took %v, want at least %v of backoff", elapsed, tt.minimum)
+			}
+		})
+	}
+}
+
+func TestGRPCDeadline(t *testing.T) {
+	t.Run("propagated", func(t *testing.T) {
+		s := &lookupServer{delay: time.Second}
+		c := newGRPCTest(t, s, WithRetry(1, 0))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := c.Lookup(ctx, "8.8.8.8")
+		if code := status.Code(err); code != codes.DeadlineExceeded {
+			t.Errorf("Lookup() error = %v, want DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Lookup() took %v past its deadline", elapsed)
+		}
+	})
+
+	t.Run("default timeout", func(t *testing.T) {
+		s := &lookupServer{delay: time.Second}
+		c := newGRPCTest(t, s, WithRetry(1, 0), WithTimeout(50*time.Millisecond))
+
+		_, err := c.Lookup(context.Background(), "8.8.8.8")
+		if code := status.Code(err); code != codes.DeadlineExceeded {
+			t.Errorf("Lookup() error = %v, want DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("backoff past the deadline", func(t *testing.T) {
+		// The first retry would wait at least 500ms, well past the
+		// deadline, so the client returns the failure at once
+		s := &lookupServer{failures: 5}
+		c := newGRPCTest(t, s, WithRetry(3, time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := c.Lookup(ctx, "8.8.8.8")
+		if code := status.Code(err); code != codes.Unavailable {
+			t.Errorf("Lookup() error = %v, want Unavailable", err)
+		}
+		if calls := s.calls.Load(); calls != 1 {
+			t.Errorf("%d calls, want 1", calls)
+		}
+		if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+			t.Errorf("Lookup() took %v instead of giving up", elapsed)
+		}
+	})
+}
+
+func TestGRPCBatch(t *testing.T) {
+	s := &lookupServer{}
+	c := newGRPCTest(t, s, WithConcurrency(3), WithCache(10, time.Minute))
+
+	if _, err := c.Lookup(context.Background(), "8.8.4.4"); err != nil {
+		t.Fatal(err)
+	}
+	queries := []string{"8.8.8.8", "8.8.4.4", "192.0.2.255", "example.com", "1.1.1.1"}
+	results, err := c.LookupBatch(context.Background(), queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, result := range results {
+		if result.Query != queries[i] {
+			t.Errorf("result %d is for %q, want %q", i, result.Query, queries[i])
+		}
+		if failed := result.Err != nil; failed != (queries[i] == "192.0.2.255") {
+			t.Errorf("result %d error = %v", i, result.Err)
+		}
+		if result.Err == nil && result.Response.Ip+result.Response.Host != queries[i] {
+			t.Errorf("result %d = %v", i, result.Response)
+		}
+	}
+	// One call per query, less the one cached by Lookup
+	if calls := s.calls.Load(); calls != 1+4 {
+		t.Errorf("%d calls, want 5", calls)
+	}
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// restBatchSize is the most addresses the service takes per POST /lookup
+const restBatchSize = 100
+
+// requestTimeoutHeader tells the service how long the client will wait,
+// so that it gives up on lookups whose results would arrive too late
+const requestTimeoutHeader = "X-Request-Timeout"
+
+// protobufType is the media type the REST transport asks for, so that it
+// decodes the same messages as the gRPC transport
+const protobufType = "application/x-protobuf"
+
+// WithHTTPClient sets the HTTP client of the REST transport. Its own
+// connection pool is used and WithPoolSize is ignored.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) { o.httpClient = client }
+}
+
+// NewREST returns a Client for the /v2 REST API at baseURL, such as
+// http://localhost:3000. Lookups are sent as GET /v2/lookup/<query> and
+// batches as POST /v2/lookup, asking for protobuf responses. Requests
+// carry what is left of their context's deadline in X-Request-Timeout.
+func NewREST(baseURL string, opts ...Option) (Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme and host are required", baseURL)
+	}
+
+	o := newOptions(opts)
+	httpClient := o.httpClient
+	if httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = o.poolSize
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	return newClient(&restTransport{
+		baseURL: u.String(),
+		http:    httpClient,
+		options: o,
+	}, o), nil
+}
+
+type restTransport struct {
+	baseURL string
+	http    *http.Client
+	options options
+}
+
+func (t *restTransport) lookup(ctx context.Context, query string) (*Response, error) {
+	path := "/v2/"
+	if query != "" {
+		path = "/v2/lookup/" + url.PathEscape(query)
+	}
+	req, err := t.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{}
+	if err := t.do(req, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (t *restTransport) lookupBatch(ctx context.Context, queries []string) ([]*Response, error) {
+	body, err := json.Marshal(queries)
+	if err != nil {
+		return nil, err
+	}
+	req, err := t.newRequest(ctx, http.MethodPost, "/v2/lookup", body)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &pbv2.LookupBatchResponse{}
+	if err := t.do(req, batch); err != nil {
+		return nil, err
+	}
+	return batch.Results, nil
+}
+
+func (t *restTransport) batchSize() int {
+	return restBatchSize
+}
+
+func (t *restTransport) close() error {
+	t.http.CloseIdleConnections()
+	return nil
+}
+
+func (t *restTransport) newRequest(ctx context.Context, method, path string, body []byte) (*http.Request, error) {
+	u := t.baseURL + path
+	if len(t.options.fields) > 0 {
+		u += "?" + url.Values{"fields": {strings.Join(t.options.fields, ",")}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", protobufType)
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+		// Rounded up, so that a budget under a millisecond is not sent as 0
+		req.Header.Set(requestTimeoutHeader, (remaining + time.Millisecond - 1).Truncate(time.Millisecond).String())
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if t.options.userAgent != "" {
+		req.Header.Set("User-Agent", t.options.userAgent)
+	}
+	return req, nil
+}
+
+// do sends req and decodes a successful response into msg. Failed
+// requests are returned as an *Error when the body says why, and wrap
+// ErrUnavailable when they are worth retrying.
+func (t *restTransport) do(req *http.Request, msg proto.Message) error {
+	res, err := t.http.Do(req)
+	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return proto.Unmarshal(body, msg)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return fmt.Errorf("%w: %s", ErrUnavailable, res.Status)
+	}
+
+	// Lookup failures are rendered in the negotiated format, and other
+	// errors as JSON
+	if strings.HasPrefix(res.Header.Get("Content-Type"), protobufType) {
+		var failed Response
+		if proto.Unmarshal(body, &failed) == nil {
+			if err := responseError(&failed); err != nil {
+				return err
+			}
+		}
+	}
+	var failed struct {
+		Error *Error `json:"error"`
+	}
+	if json.Unmarshal(body, &failed) == nil && failed.Error != nil {
+		return failed.Error
+	}
+	return fmt.Errorf("ip2location: unexpected response %s", res.Status)
+}
@@ -0,0 +1,340 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// restServer stands in for the /v2 REST API. The first failures requests
+// are answered with failStatus, 503 unless set.
+type restServer struct {
+	failures   int32
+	failStatus int
+
+	requests atomic.Int32
+	mu       sync.Mutex
+	headers  []http.Header
+	paths    []string
+	batches  [][]string
+}
+
+func (s *restServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := s.requests.Add(1)
+	s.mu.Lock()
+	s.headers = append(s.headers, r.Header.Clone())
+	s.paths = append(s.paths, r.URL.RequestURI())
+	s.mu.Unlock()
+
+	if n <= s.failures {
+		status := s.failStatus
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	var msg proto.Message
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v2/lookup/"):
+		query := strings.TrimPrefix(r.URL.Path, "/v2/lookup/")
+		switch query {
+		case "invalid":
+			// Request errors are JSON whatever the Accept header says
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"code": "invalid_request", "message": "invalid IP address format"}}`))
+			return
+		case "broken":
+			http.Error(w, "oops", http.StatusInternalServerError)
+			return
+		}
+		response := lookupResponse(query)
+		if response.Error != nil {
+			w.Header().Set("Content-Type", protobufType)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		msg = response
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/":
+		msg = &pbv2.LookupResponse{Ip: "198.51.100.7"}
+	case r.Method == http.MethodPost && r.URL.Path == "/v2/lookup":
+		var queries []string
+		if err := json.NewDecoder(r.Body).Decode(&queries); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.batches = append(s.batches, queries)
+		s.mu.Unlock()
+		batch := &pbv2.LookupBatchResponse{}
+		for _, query := range queries {
+			batch.Results = append(batch.Results, lookupResponse(query))
+		}
+		msg = batch
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	body, _ := proto.Marshal(msg)
+	w.Write(body)
+}
+
+// lookupResponse answers a query, failing for 192.0.2.255
+func lookupResponse(query string) *pbv2.LookupResponse {
+	if query == "192.0.2.255" {
+		return &pbv2.LookupResponse{Ip: query, Error: &pbv2.Error{Code: "lookup_failed", Message: "Failed to lookup IP address"}}
+	}
+	return &pbv2.LookupResponse{Ip: query}
+}
+
+func newRESTTest(t *testing.T, s *restServer, opts ...Option) Client {
+	t.Helper()
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	c, err := NewREST(server.URL+"/", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestNewRESTInvalidURL(t *testing.T) {
+	for _, baseURL := range []string{"localhost:3000", "/v2", "http://%zz"} {
+		if _, err := NewREST(baseURL); err == nil {
+			t.Errorf("NewREST(%q) succeeded", baseURL)
+		}
+	}
+}
+
+func TestRESTLookup(t *testing.T) {
+	s := &restServer{}
+	c := newRESTTest(t, s, WithFields("providers.maxmind.country", "device.os"), WithUserAgent("test/1.0"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	response, err := c.Lookup(ctx, "2001:db8::1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Ip != "2001:db8::1" {
+		t.Errorf("Lookup() = %v", response)
+	}
+	if response, err := c.Lookup(context.Background(), ""); err != nil || response.Ip != "198.51.100.7" {
+		t.Errorf("Lookup(\"\") = %v, %v", response, err)
+	}
+
+	if want := "/v2/lookup/2001:db8::1?fields=providers.maxmind.country%2Cdevice.os"; s.paths[0] != want {
+		t.Errorf("GET %s, want %s", s.paths[0], want)
+	}
+	if !strings.HasPrefix(s.paths[1], "/v2/?") {
+		t.Errorf("GET %s for the caller's address", s.paths[1])
+	}
+	header := s.headers[0]
+	if header.Get("Accept") != protobufType || header.Get("User-Agent") != "test/1.0" {
+		t.Errorf("request headers = %v", header)
+	}
+
+	// The remaining budget of the context is sent along
+	timeout, err := time.ParseDuration(header.Get(requestTimeoutHeader))
+	if err != nil || timeout <= 0 || timeout > 2*time.Second {
+		t.Errorf("%s = %q, want at most 2s", requestTimeoutHeader, header.Get(requestTimeoutHeader))
+	}
+	// Without a deadline of its own, a call gets DefaultTimeout's
+	timeout, err = time.ParseDuration(s.headers[1].Get(requestTimeoutHeader))
+	if err != nil || timeout <= 2*time.Second || timeout > DefaultTimeout {
+		t.Errorf("%s = %q, want close to %v", requestTimeoutHeader, s.headers[1].Get(requestTimeoutHeader), DefaultTimeout)
+	}
+}
+
+func TestRESTWithoutDeadline(t *testing.T) {
+	s := &restServer{}
+	c := newRESTTest(t, s, WithTimeout(0))
+
+	if _, err := c.Lookup(context.Background(), "8.8.8.8"); err != nil {
+		t.Fatal(err)
+	}
+	if value := s.headers[0].Get(requestTimeoutHeader); value != "" {
+		t.Errorf("%s = %q without a deadline", requestTimeoutHeader, value)
+	}
+}
+
+func TestRESTErrors(t *testing.T) {
+	s := &restServer{}
+	c := newRESTTest(t, s, WithRetry(3, time.Millisecond))
+
+	tests := []struct {
+		query string
+		code  string
+	}{
+		{query: "192.0.2.255", code: "lookup_failed"},
+		{query: "invalid", code: "invalid_request"},
+	}
+	for _, tt := range tests {
+		_, err := c.Lookup(context.Background(), tt.query)
+		var lookupErr *Error
+		if !errors.As(err, &lookupErr) || lookupErr.Code != tt.code {
+			t.Errorf("Lookup(%s) error = %v, want %s", tt.query, err, tt.code)
+		}
+	}
+
+	before := s.requests.Load()
+	_, err := c.Lookup(context.Background(), "broken")
+	if err == nil || errors.Is(err, ErrUnavailable) {
+		t.Errorf("Lookup(broken) error = %v, want an unexpected response", err)
+	}
+	if sent := s.requests.Load() - before; sent != 1 {
+		t.Errorf("a 500 was sent %d times", sent)
+	}
+}
+
+func TestRESTRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      *restServer
+		requests    int32
+		unavailable bool
+	}{
+		{name: "503", server: &restServer{failures: 2}, requests: 3},
+		{name: "502", server: &restServer{failures: 1, failStatus: http.StatusBadGateway}, requests: 2},
+		{name: "504", server: &restServer{failures: 1, failStatus: http.StatusGatewayTimeout}, requests: 2},
+		{name: "gives up", server: &restServer{failures: 5}, requests: 3, unavailable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newRESTTest(t, tt.server, WithRetry(3, time.Millisecond))
+			_, err := c.Lookup(context.Background(), "8.8.8.8")
+			if errors.Is(err, ErrUnavailable) != tt.unavailable || (err != nil && !tt.unavailable) {
+				t.Errorf("Lookup() error = %v", err)
+			}
+			if requests := tt.server.requests.Load(); requests != tt.requests {
+				t.Errorf("%d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+
+	t.Run("connection refused", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		c, err := NewREST(server.URL, WithRetry(2, time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Lookup(context.Background(), "8.8.8.8"); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Lookup() error = %v, want ErrUnavailable", err)
+		}
+	})
+}
+
+func TestRESTBatchChunking(t *testing.T) {
+	s := &restServer{}
+	c := newRESTTest(t, s, WithCache(1000, time.Minute), WithConcurrency(2))
+
+	queries := make([]string, 2*restBatchSize+50)
+	for i := range queries {
+		queries[i] = "10.0." + strconv.Itoa(i/256) + "." + strconv.Itoa(i%256)
+	}
+	queries[7] = "192.0.2.255"
+	// Cached queries are answered without being sent
+	for _, query := range queries[:10] {
+		if query == "192.0.2.255" {
+			continue
+		}
+		if _, err := c.Lookup(context.Background(), query); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := c.LookupBatch(context.Background(), queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range results {
+		if result.Query != queries[i] {
+			t.Fatalf("result %d is for %q, want %q", i, result.Query, queries[i])
+		}
+		if i == 7 {
+			if result.Err == nil {
+				t.Error("the failed lookup has no error")
+			}
+			continue
+		}
+		if result.Err != nil || result.Response.Ip != queries[i] {
+			t.Errorf("result %d = %v, %v", i, result.Response, result.Err)
+		}
+	}
+
+	var sizes []int
+	sent := 0
+	for _, batch := range s.batches {
+		sizes = append(sizes, len(batch))
+		sent += len(batch)
+	}
+	if len(s.batches) != 3 || sent != len(queries)-9 {
+		t.Errorf("sent batches of %v, want %d queries in batches of at most %d", sizes, len(queries)-9, restBatchSize)
+	}
+	for _, size := range sizes {
+		if size > restBatchSize {
+			t.Errorf("a batch of %d queries", size)
+		}
+	}
+
+	// The batch filled the cache
+	before := s.requests.Load()
+	if _, err := c.Lookup(context.Background(), queries[len(queries)-1]); err != nil {
+		t.Fatal(err)
+	}
+	if s.requests.Load() != before {
+		t.Error("a batch result was not cached")
+	}
+}
+
+func TestRESTCache(t *testing.T) {
+	s := &restServer{}
+	c := newRESTTest(t, s, WithCache(1, 50*time.Millisecond))
+
+	lookup := func(query string) {
+		t.Helper()
+		if _, err := c.Lookup(context.Background(), query); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lookup("8.8.8.8")
+	lookup("8.8.8.8")
+	if n := s.requests.Load(); n != 1 {
+		t.Errorf("%d requests for a cached query", n)
+	}
+
+	// Failures are not cached
+	c.Lookup(context.Background(), "192.0.2.255")
+	c.Lookup(context.Background(), "192.0.2.255")
+	if n := s.requests.Load(); n != 3 {
+		t.Errorf("%d requests, want a failed query sent twice", n)
+	}
+
+	// A second query evicts the first from the one-entry cache
+	lookup("8.8.4.4")
+	lookup("8.8.8.8")
+	if n := s.requests.Load(); n != 5 {
+		t.Errorf("%d requests, want an evicted query sent again", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	lookup("8.8.8.8")
+	if n := s.requests.Load(); n != 6 {
+		t.Errorf("%d requests, want an expired query sent again", n)
+	}
+}
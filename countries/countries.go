@@ -0,0 +1,336 @@
+package countries
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed countries.yaml
+var defaultCountries []byte
+
+//go:embed subdivisions.yaml
+var defaultSubdivisions []byte
+
+// Dataset is the on-disk format of countries.yaml and subdivisions.yaml
+type Dataset struct {
+	Continents   map[string]string `yaml:"continents"`
+	Languages    map[string]string `yaml:"languages"`
+	Countries    []Record          `yaml:"countries"`
+	Subdivisions []Subdivision     `yaml:"subdivisions"`
+}
+
+// Subdivision is an ISO 3166-2 subdivision. Code includes the country,
+// as in US-CA.
+type Subdivision struct {
+	Code string `yaml:"code"`
+	Name string `yaml:"name"`
+}
+
+// Country returns the alpha-2 code of the subdivision's country
+func (s *Subdivision) Country() string {
+	country, _, _ := strings.Cut(s.Code, "-")
+	return country
+}
+
+// Record is a country as listed in the dataset, with continents and
+// languages given by code
+type Record struct {
+	Alpha2       string   `yaml:"alpha2"`
+	Alpha3       string   `yaml:"alpha3"`
+	Numeric      string   `yaml:"numeric"`
+	Name         string   `yaml:"name"`
+	OfficialName string   `yaml:"official_name"`
+	Currencies   []string `yaml:"currencies"`
+	Languages    []string `yaml:"languages"`
+	CallingCodes []string `yaml:"calling_codes"`
+	TLDs         []string `yaml:"tlds"`
+	Continent    string   `yaml:"continent"`
+	EU           bool     `yaml:"eu"`
+	EEA          bool     `yaml:"eea"`
+}
+
+// Country is a country with its codes and names resolved
+type Country struct {
+	Alpha2       string
+	Alpha3       string
+	Numeric      string
+	Name         string
+	OfficialName string
+	// Currencies are ISO 4217 codes
+	Currencies []string
+	Languages  []Language
+	// CallingCodes are international calling codes, without the +
+	CallingCodes []string
+	TLDs         []string
+	Continent    Continent
+	EU           bool
+	EEA          bool
+}
+
+// Language is an official language, identified by its ISO 639-3 code
+type Language struct {
+	Code string
+	Name string
+}
+
+// Continent is one of AF, AN, AS, EU, NA, OC and SA with its name
+type Continent struct {
+	Code string
+	Name string
+}
+
+// GDPR reports whether the General Data Protection Regulation applies,
+// which it does throughout the EEA
+func (c *Country) GDPR() bool {
+	return c.EEA
+}
+
+// Flag returns the flag emoji, spelled with the regional indicator symbols
+// of the alpha-2 code
+func (c *Country) Flag() string {
+	if len(c.Alpha2) != 2 {
+		return ""
+	}
+	var b strings.Builder
+	for _, r := range c.Alpha2 {
+		b.WriteRune(0x1F1E6 + r - 'A')
+	}
+	return b.String()
+}
+
+// Index finds countries by any of their ISO 3166-1 codes, and their
+// subdivisions by ISO 3166-2 code or name
+type Index struct {
+	countries []Country
+	byCode    map[string]*Country
+
+	subdivisions map[string]*Subdivision
+	// byName maps a country's alpha-2 code and a folded subdivision name
+	// to the subdivision
+	byName map[string]map[string]*Subdivision
+}
+
+// Load reads one or more dataset files, such as countries.yaml and
+// subdivisions.yaml, and indexes them
+func Load(readers ...io.Reader) (*Index, error) {
+	var data Dataset
+	for _, r := range readers {
+		var part Dataset
+		if err := yaml.NewDecoder(r).Decode(&part); err != nil {
+			return nil, fmt.Errorf("failed to parse country data: %w", err)
+		}
+		data.merge(&part)
+	}
+
+	index := &Index{
+		countries: make([]Country, len(data.Countries)),
+		byCode:    make(map[string]*Country, 3*len(data.Countries)),
+	}
+	for i, record := range data.Countries {
+		continent, ok := data.Continents[record.Continent]
+		if !ok {
+			return nil, fmt.Errorf("country %s: unknown continent %q", record.Alpha2, record.Continent)
+		}
+
+		country := Country{
+			Alpha2:       record.Alpha2,
+			Alpha3:       record.Alpha3,
+			Numeric:      record.Numeric,
+			Name:         record.Name,
+			OfficialName: record.OfficialName,
+			Currencies:   record.Currencies,
+			CallingCodes: record.CallingCodes,
+			TLDs:         record.TLDs,
+			Continent:    Continent{Code: record.Continent, Name: continent},
+			EU:           record.EU,
+			EEA:          record.EEA,
+		}
+		for _, code := range record.Languages {
+			name, ok := data.Languages[code]
+			if !ok {
+				return nil, fmt.Errorf("country %s: unknown language %q", record.Alpha2, code)
+			}
+			country.Languages = append(country.Languages, Language{Code: code, Name: name})
+		}
+
+		index.countries[i] = country
+	}
+
+	sort.Slice(index.countries, func(i, j int) bool {
+		return index.countries[i].Alpha2 < index.countries[j].Alpha2
+	})
+	for i := range index.countries {
+		country := &index.countries[i]
+		for _, code := range []string{country.Alpha2, country.Alpha3, country.Numeric} {
+			if code != "" {
+				index.byCode[code] = country
+			}
+		}
+	}
+
+	if err := index.addSubdivisions(data.Subdivisions); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (d *Dataset) merge(part *Dataset) {
+	if d.Continents == nil {
+		d.Continents = make(map[string]string)
+	}
+	if d.Languages == nil {
+		d.Languages = make(map[string]string)
+	}
+	for code, name := range part.Continents {
+		d.Continents[code] = name
+	}
+	for code, name := range part.Languages {
+		d.Languages[code] = name
+	}
+	d.Countries = append(d.Countries, part.Countries...)
+	d.Subdivisions = append(d.Subdivisions, part.Subdivisions...)
+}
+
+// addSubdivisions indexes subdivisions by code and by name. Names are also
+// indexed without a trailing designation, so "Beijing" finds "Beijing Shi";
+// where names collide the first subdivision listed wins.
+func (x *Index) addSubdivisions(subdivisions []Subdivision) error {
+	x.subdivisions = make(map[string]*Subdivision, len(subdivisions))
+	x.byName = make(map[string]map[string]*Subdivision)
+
+	for i := range subdivisions {
+		subdivision := &subdivisions[i]
+		country := subdivision.Country()
+		if _, ok := x.byCode[country]; !ok {
+			return fmt.Errorf("subdivision %s: unknown country", subdivision.Code)
+		}
+
+		x.subdivisions[subdivision.Code] = subdivision
+		if x.byName[country] == nil {
+			x.byName[country] = make(map[string]*Subdivision)
+		}
+		name := Fold(subdivision.Name)
+		if _, ok := x.byName[country][name]; !ok {
+			x.byName[country][name] = subdivision
+		}
+	}
+
+	for i := range subdivisions {
+		subdivision := &subdivisions[i]
+		name, ok := trimDesignation(Fold(subdivision.Name))
+		if !ok {
+			continue
+		}
+		names := x.byName[subdivision.Country()]
+		if _, ok := names[name]; !ok {
+			names[name] = subdivision
+		}
+	}
+	return nil
+}
+
+// Subdivision returns the subdivision with an ISO 3166-2 code, ignoring
+// case
+func (x *Index) Subdivision(code string) (*Subdivision, bool) {
+	subdivision, ok := x.subdivisions[strings.ToUpper(strings.TrimSpace(code))]
+	return subdivision, ok
+}
+
+// FindSubdivision returns the subdivision of a country, given by alpha-2
+// code, with a name. Case, accents and punctuation are ignored.
+func (x *Index) FindSubdivision(country, name string) (*Subdivision, bool) {
+	names := x.byName[strings.ToUpper(country)]
+	if names == nil || name == "" {
+		return nil, false
+	}
+	if subdivision, ok := names[Fold(name)]; ok {
+		return subdivision, true
+	}
+	if name, ok := trimDesignation(Fold(name)); ok {
+		subdivision, ok := names[name]
+		return subdivision, ok
+	}
+	return nil, false
+}
+
+// Lookup returns the country with an alpha-2, alpha-3 or numeric code,
+// ignoring case
+func (x *Index) Lookup(code string) (*Country, bool) {
+	country, ok := x.byCode[strings.ToUpper(strings.TrimSpace(code))]
+	return country, ok
+}
+
+// All returns every country, ordered by alpha-2 code
+func (x *Index) All() []Country {
+	return x.countries
+}
+
+var defaultIndex = func() *Index {
+	index, err := Load(bytes.NewReader(defaultCountries), bytes.NewReader(defaultSubdivisions))
+	if err != nil {
+		panic(err)
+	}
+	return index
+}()
+
+// Default returns the index of the dataset embedded in the binary
+func Default() *Index {
+	return defaultIndex
+}
+
+// Lookup finds a country in the embedded dataset
+func Lookup(code string) (*Country, bool) {
+	return defaultIndex.Lookup(code)
+}
+
+// LookupSubdivision finds a subdivision in the embedded dataset by ISO
+// 3166-2 code
+func LookupSubdivision(code string) (*Subdivision, bool) {
+	return defaultIndex.Subdivision(code)
+}
+
+// FindSubdivision finds a subdivision in the embedded dataset by name
+func FindSubdivision(country, name string) (*Subdivision, bool) {
+	return defaultIndex.FindSubdivision(country, name)
+}
+
+// designations are the words that trail subdivision names in some sources
+// and not in others
+var designations = []string{
+	"autonomousregion", "province", "prefecture", "region", "state", "county",
+	"district", "governorate", "oblast", "sheng", "shi",
+}
+
+func trimDesignation(name string) (string, bool) {
+	for _, designation := range designations {
+		if trimmed := strings.TrimSuffix(name, designation); trimmed != name && trimmed != "" {
+			return trimmed, true
+		}
+	}
+	return "", false
+}
+
+// Fold reduces a name to lower-case letters and digits without accents, so
+// that "Île-de-France" and "Ile de France" compare equal
+func Fold(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)))
+	name, _, _ = transform.String(t, name)
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
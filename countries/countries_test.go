@@ -0,0 +1,184 @@
+package countries
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDefault checks that the embedded dataset loads and that every
+// country is found by each of its codes
+func TestDefault(t *testing.T) {
+	all := Default().All()
+	if len(all) != 250 {
+		t.Errorf("the dataset has %d countries, want 250", len(all))
+	}
+
+	for i := range all {
+		country := &all[i]
+		if len(country.Alpha2) != 2 || len(country.Alpha3) != 3 || (country.Numeric != "" && len(country.Numeric) != 3) {
+			t.Errorf("%s has malformed codes %s, %s and %q", country.Name, country.Alpha2, country.Alpha3, country.Numeric)
+		}
+		if i > 0 && all[i-1].Alpha2 >= country.Alpha2 {
+			t.Errorf("%s is listed after %s", country.Alpha2, all[i-1].Alpha2)
+		}
+		if country.Name == "" || country.Continent.Name == "" {
+			t.Errorf("%s has no name or continent: %+v", country.Alpha2, country)
+		}
+
+		for _, code := range []string{country.Alpha2, country.Alpha3, country.Numeric, strings.ToLower(country.Alpha3)} {
+			if code == "" {
+				continue
+			}
+			if found, ok := Lookup(code); !ok || found != country {
+				t.Errorf("Lookup(%q) = %v, %v; want %s", code, found, ok, country.Alpha2)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		code   string
+		alpha2 string
+	}{
+		{"US", "US"},
+		{"usa", "US"},
+		{"840", "US"},
+		{" de ", "DE"},
+		{"276", "DE"},
+		// Numeric codes keep their leading zeros
+		{"004", "AF"},
+		{"4", ""},
+		// Kosovo has no numeric code
+		{"XKX", "XK"},
+		{"", ""},
+		{"ZZ", ""},
+		{"EU", ""},
+	}
+	for _, tt := range tests {
+		country, ok := Lookup(tt.code)
+		got := ""
+		if ok {
+			got = country.Alpha2
+		}
+		if got != tt.alpha2 {
+			t.Errorf("Lookup(%q) = %q, want %q", tt.code, got, tt.alpha2)
+		}
+	}
+
+	germany, _ := Lookup("DE")
+	if !germany.EU || !germany.GDPR() || germany.Flag() != "\U0001F1E9\U0001F1EA" ||
+		germany.Continent != (Continent{Code: "EU", Name: "Europe"}) ||
+		len(germany.Languages) != 1 || germany.Languages[0] != (Language{Code: "deu", Name: "German"}) {
+		t.Errorf("Lookup(DE) = %+v", germany)
+	}
+	if norway, _ := Lookup("NO"); norway.EU || !norway.GDPR() {
+		t.Errorf("Norway is in the EU or outside the EEA: %+v", norway)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	const countries = `
+continents: {EU: Europe}
+languages: {deu: German}
+countries:
+  - {alpha2: DE, alpha3: DEU, numeric: "276", name: Germany, languages: [deu], continent: EU}
+`
+	index, err := Load(strings.NewReader(countries), strings.NewReader("subdivisions: [{code: DE-BY, name: Bayern}]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.All()) != 1 {
+		t.Errorf("All() = %+v", index.All())
+	}
+	if _, ok := index.Subdivision("DE-BY"); !ok {
+		t.Error("Subdivision(DE-BY) not found")
+	}
+
+	tests := map[string]string{
+		"malformed":         "countries: {",
+		"unknown continent": "countries: [{alpha2: DE, continent: XX}]",
+		"unknown language":  "continents: {EU: Europe}\ncountries: [{alpha2: DE, continent: EU, languages: [xxx]}]",
+		"unknown country":   "subdivisions: [{code: FR-IDF, name: Ile-de-France}]",
+	}
+	for name, data := range tests {
+		if _, err := Load(strings.NewReader(data)); err == nil {
+			t.Errorf("Load(%s) succeeded", name)
+		}
+	}
+}
+
+func TestLookupSubdivision(t *testing.T) {
+	tests := map[string]string{
+		"US-CA":   "California",
+		"us-ca":   "California",
+		" DE-BY ": "Bayern",
+		"RU-MOW":  "Moskva",
+		"US-XX":   "",
+		"US":      "",
+		"":        "",
+	}
+	for code, want := range tests {
+		subdivision, ok := LookupSubdivision(code)
+		if want == "" {
+			if ok {
+				t.Errorf("LookupSubdivision(%q) = %+v, want none", code, subdivision)
+			}
+			continue
+		}
+		if !ok || subdivision.Name != want {
+			t.Errorf("LookupSubdivision(%q) = %+v, %v; want %s", code, subdivision, ok, want)
+		}
+	}
+
+	if subdivision, _ := LookupSubdivision("FR-IDF"); subdivision.Country() != "FR" {
+		t.Errorf("Country() = %q, want FR", subdivision.Country())
+	}
+}
+
+func TestFindSubdivision(t *testing.T) {
+	tests := []struct {
+		country, name string
+		want          string
+	}{
+		{"US", "California", "US-CA"},
+		{"us", "CALIFORNIA", "US-CA"},
+		// Accents and punctuation are ignored
+		{"FR", "Ile de France", "FR-IDF"},
+		{"FR", "Île-de-France", "FR-IDF"},
+		// Designations are dropped from either side
+		{"CN", "Beijing", "CN-BJ"},
+		{"CN", "Beijing Shi", "CN-BJ"},
+		{"CA", "Ontario Province", "CA-ON"},
+		// The name must belong to the country
+		{"CA", "California", ""},
+		{"US", "Atlantis", ""},
+		{"US", "", ""},
+		{"ZZ", "California", ""},
+		// A designation alone names nothing
+		{"US", "State", ""},
+	}
+	for _, tt := range tests {
+		subdivision, ok := FindSubdivision(tt.country, tt.name)
+		got := ""
+		if ok {
+			got = subdivision.Code
+		}
+		if got != tt.want {
+			t.Errorf("FindSubdivision(%q, %q) = %q, want %q", tt.country, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := map[string]string{
+		"Île-de-France":     "iledefrance",
+		"São Paulo":         "saopaulo",
+		"Baden-Württemberg": "badenwurttemberg",
+	}
+	for name, want := range tests {
+		if got := Fold(name); got != want {
+			t.Errorf("Fold(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
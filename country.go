@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/countries"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+)
+
+// handleCountry serves the metadata of one country, by ISO 3166-1 alpha-2,
+// alpha-3 or numeric code
+func handleCountry(c *fiber.Ctx) error {
+	country, ok := countries.Lookup(c.Params("code"))
+	if !ok {
+		return writeError(c, fiber.StatusNotFound, "unknown country code")
+	}
+	return c.JSON(countryV2(country))
+}
+
+// toCountryV2 describes the country a provider reported by its code. Codes
+// missing from the dataset keep only what the provider gave.
+func toCountryV2(code, name string) *CountryV2 {
+	if code == "" && name == "" {
+		return nil
+	}
+	country, ok := countries.Lookup(code)
+	if !ok {
+		return &CountryV2{Code: code, Name: name}
+	}
+
+	return countryV2(country)
+}
+
+func countryV2(country *countries.Country) *CountryV2 {
+	out := &CountryV2{
+		Code:         country.Alpha2,
+		Name:         country.Name,
+		Alpha3:       country.Alpha3,
+		Numeric:      country.Numeric,
+		OfficialName: country.OfficialName,
+		Flag:         country.Flag(),
+		Continent:    &PlaceV2{Code: country.Continent.Code, Name: country.Continent.Name},
+		Currencies:   country.Currencies,
+		TLDs:         country.TLDs,
+		EU:           country.EU,
+		EEA:          country.EEA,
+		GDPR:         country.GDPR(),
+	}
+	for _, language := range country.Languages {
+		out.Languages = append(out.Languages, LanguageV2{Code: language.Code, Name: language.Name})
+	}
+	for _, code := range country.CallingCodes {
+		out.CallingCodes = append(out.CallingCodes, "+"+code)
+	}
+	return out
+}
+
+// GetCountry implements the v2 country metadata method
+func (s *GRPCServerV2) GetCountry(ctx context.Context, req *pbv2.GetCountryRequest) (*pbv2.GetCountryResponse, error) {
+	country, ok := countries.Lookup(req.Code)
+	if !ok {
+		return &pbv2.GetCountryResponse{
+			Error: &pbv2.Error{Code: errorCode(http.StatusNotFound), Message: "unknown country code"},
+		}, nil
+	}
+	return &pbv2.GetCountryResponse{Country: toPBCountryV2(countryV2(country))}, nil
+}
+
+func toPBCountryV2(country *CountryV2) *pbv2.Country {
+	if country == nil {
+		return nil
+	}
+	out := &pbv2.Country{
+		Code:         country.Code,
+		Name:         country.Name,
+		GeonameId:    uint32(country.GeoNameID),
+		Alpha3:       country.Alpha3,
+		Numeric:      country.Numeric,
+		OfficialName: country.OfficialName,
+		Flag:         country.Flag,
+		Continent:    toPBPlaceV2(country.Continent),
+		Currencies:   country.Currencies,
+		CallingCodes: country.CallingCodes,
+		Tlds:         country.TLDs,
+		Eu:           country.EU,
+		Eea:          country.EEA,
+		Gdpr:         country.GDPR,
+	}
+	for _, language := range country.Languages {
+		out.Languages = append(out.Languages, &pbv2.Language{Code: language.Code, Name: language.Name})
+	}
+	return out
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestHandleCountry(t *testing.T) {
+	a := newRPCApp(t)
+
+	for _, code := range []string{"DE", "deu", "276"} {
+		res, body := send(t, a, "GET", "/v2/countries/"+code, "", nil)
+		var got CountryV2
+		if err := json.Unmarshal(body, &got); err != nil || res.StatusCode != fiber.StatusOK || got.Code != "DE" || got.Alpha3 != "DEU" {
+			t.Errorf("/v2/countries/%s: got %d %s", code, res.StatusCode, body)
+		}
+	}
+	res, body := send(t, a, "GET", "/v2/countries/XX", "", nil)
+	if res.StatusCode != fiber.StatusNotFound || !strings.Contains(string(body), `"code":"not_found"`) {
+		t.Errorf("/v2/countries/XX: got %d %s", res.StatusCode, body)
+	}
+
+	// Country metadata is only part of v2
+	for _, target := range []string{"/v1/countries/DE", "/countries/DE"} {
+		if res, _ := send(t, a, "GET", target, "", nil); res.StatusCode != fiber.StatusNotFound {
+			t.Errorf("%s: got %d, want 404", target, res.StatusCode)
+		}
+	}
+}
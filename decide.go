@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/ip2location"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"github.com/imnitish-dev/ip2location/rules"
+)
+
+// errRulesDisabled is reported by /v2/decide when RULES_PATH is unset
+const errRulesDisabled = "rules are not configured"
+
+// errNoLocation is reported by /v2/decide when no provider answered, as
+// rules over an empty location would fall through to the default
+var errNoLocation = errors.New("no location provider answered")
+
+// DecideRequestV2 asks for a decision on an address and device. The
+// caller's address and User-Agent are used for fields left empty.
+type DecideRequestV2 struct {
+	IP        string            `json:"ip,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+	Hints     map[string]string `json:"hints,omitempty"`
+}
+
+// DecisionV2 is the outcome of the geofencing rules. Rule is empty when
+// no rule matched and the default decision applied. A rule that fails to
+// evaluate turns anything but a deny into a review by that rule.
+type DecisionV2 struct {
+	Decision    string        `json:"decision"`
+	Rule        string        `json:"rule,omitempty"`
+	Explanation string        `json:"explanation"`
+	IP          string        `json:"ip"`
+	DryRun      []RuleMatchV2 `json:"dry_run,omitempty"`
+}
+
+// RuleMatchV2 is a dry-run rule that matched
+type RuleMatchV2 struct {
+	Rule        string `json:"rule"`
+	Decision    string `json:"decision"`
+	Explanation string `json:"explanation"`
+}
+
+// newRules loads the rules file and starts watching it for changes; rules
+// are disabled when RULES_PATH is empty
+func newRules(config *Config) (*rules.Engine, func(), error) {
+	if config.RulesPath == "" {
+		return nil, func() {}, nil
+	}
+
+	engine, err := rules.NewEngine(config.RulesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("Loaded %d rules from %s", engine.Rules().Len(), config.RulesPath)
+	return engine, engine.Watch(config.RulesReloadInterval), nil
+}
+
+// handleDecide evaluates the rules for the address and device in the body
+func (a *App) handleDecide(c *fiber.Ctx) error {
+	if a.rules == nil {
+		return writeError(c, fiber.StatusServiceUnavailable, errRulesDisabled)
+	}
+
+	var req DecideRequestV2
+	if body := bytes.TrimSpace(c.Body()); len(body) > 0 {
+		if err := c.App().Config().JSONDecoder(body, &req); err != nil {
+			return writeError(c, fiber.StatusBadRequest, "invalid request body: "+err.Error())
+		}
+	}
+
+	clientIP := getClientIP(c)
+	ip := clientIP
+	if req.IP != "" {
+		ip = req.IP
+	}
+	ip, err := parseIP(ip)
+	if err != nil {
+		return writeError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	var device DeviceInfo
+	if req.UserAgent != "" || len(req.Hints) > 0 {
+		device = a.ua.ParseWithHints(req.UserAgent, hintsFromMap(req.Hints))
+	} else {
+		device = a.getDeviceInfo(c)
+	}
+
+	decision, err := a.decide(c.UserContext(), ip, clientIP, &device)
+	if err != nil {
+		return writeError(c, fiber.StatusServiceUnavailable, err.Error())
+	}
+	return c.JSON(decision)
+}
+
+// decide geolocates ip and evaluates the rules over the result. It fails
+// with errNoLocation when no provider answered. Rules that fail to
+// evaluate are logged and, unless they are dry runs, keep the request
+// from being allowed.
+func (a *App) decide(ctx context.Context, ip, clientIP string, device *DeviceInfo) (DecisionV2, error) {
+	result := a.lookup(ctx, ip, lookupWork{maxmind: true, ip2location: true, timeZone: true})
+	if len(result.Locations) == 0 || result.Merged == nil {
+		return DecisionV2{}, noLocationError(result.Errors)
+	}
+	providers := toProvidersV2(
+		result.Locations[string(ip2location.MaxMindProvider)],
+		result.Locations[string(ip2location.IP2LocationProvider)],
+	)
+
+	// location is the merged result, which prefers MaxMind field by field
+	in := rules.Input{
+		IP:        ip,
+		ClientIP:  clientIP,
+		Location:  ruleValue(toLocationV2(result.Merged)),
+		Providers: ruleValue(providers),
+		Device:    ruleValue(toDeviceV2(device)),
+	}
+
+	decision, errs := a.rules.Decide(in)
+	var failed *rules.RuleError
+	for _, err := range errs {
+		log.Printf("Rule evaluation failed for %s: %v", ip, err)
+		var ruleErr *rules.RuleError
+		if failed == nil && errors.As(err, &ruleErr) && !ruleErr.DryRun {
+			failed = ruleErr
+		}
+	}
+
+	out := DecisionV2{
+		Decision:    decision.Decision,
+		Rule:        decision.Rule,
+		Explanation: decision.Explanation,
+		IP:          ip,
+	}
+	// A failed rule might have denied the request, so only a deny by a
+	// later rule stands
+	if failed != nil && out.Decision != rules.Deny {
+		out.Decision = rules.Review
+		out.Rule = failed.Rule
+		out.Explanation = "rule failed to evaluate: " + failed.Err.Error()
+	}
+	for _, match := range decision.DryRun {
+		log.Printf("Dry-run rule %s would %s %s", match.Rule, match.Decision, ip)
+		out.DryRun = append(out.DryRun, RuleMatchV2{
+			Rule:        match.Rule,
+			Decision:    match.Decision,
+			Explanation: match.Explanation,
+		})
+	}
+	return out, nil
+}
+
+// noLocationError is errNoLocation with the failures of the providers
+func noLocationError(failures map[string]error) error {
+	if len(failures) == 0 {
+		return errNoLocation
+	}
+	names := make([]string, 0, len(failures))
+	for name := range failures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	reasons := make([]string, len(names))
+	for i, name := range names {
+		reasons[i] = fmt.Sprintf("%s: %v", name, failures[name])
+	}
+	return fmt.Errorf("%w (%s)", errNoLocation, strings.Join(reasons, "; "))
+}
+
+// ruleValue converts a /v2 value to the map rules see, keyed by its JSON
+// field names
+func ruleValue(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// Decide implements the v2 geofencing method. When no IP is given, the
+// caller's address is decided on.
+func (s *GRPCServerV2) Decide(ctx context.Context, req *pbv2.DecideRequest) (*pbv2.DecideResponse, error) {
+	if s.app.rules == nil {
+		return &pbv2.DecideResponse{
+			Error: &pbv2.Error{Code: errorCode(http.StatusServiceUnavailable), Message: errRulesDisabled},
+		}, nil
+	}
+
+	clientIP := peerIP(ctx)
+	ip := clientIP
+	if req.Ip != "" {
+		ip = req.Ip
+	}
+	ip, err := parseIP(ip)
+	if err != nil {
+		return &pbv2.DecideResponse{
+			Error: &pbv2.Error{Code: errorCode(http.StatusBadRequest), Message: err.Error()},
+		}, nil
+	}
+
+	var device DeviceInfo
+	if len(req.Hints) > 0 {
+		device = s.app.ua.ParseWithHints(req.UserAgent, hintsFromMap(req.Hints))
+	} else {
+		device = s.app.grpcDevice(ctx, req.UserAgent)
+	}
+
+	decision, err := s.app.decide(ctx, ip, clientIP, &device)
+	if err != nil {
+		return &pbv2.DecideResponse{
+			Ip:    ip,
+			Error: &pbv2.Error{Code: errorCode(http.StatusServiceUnavailable), Message: err.Error()},
+		}, nil
+	}
+	out := &pbv2.DecideResponse{
+		Decision:    decision.Decision,
+		Rule:        decision.Rule,
+		Explanation: decision.Explanation,
+		Ip:          decision.IP,
+	}
+	for _, match := range decision.DryRun {
+		out.DryRun = append(out.DryRun, &pbv2.RuleMatch{
+			Rule:        match.Rule,
+			Decision:    match.Decision,
+			Explanation: match.Explanation,
+		})
+	}
+	return out, nil
+}
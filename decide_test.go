@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/ip2location"
+	"github.com/imnitish-dev/ip2location/rules"
+	"github.com/imnitish-dev/ip2location/useragent"
+)
+
+// stubLocator answers every address with a copy of location, or with err
+type stubLocator struct {
+	location *ip2location.Location
+	err      error
+	calls    atomic.Int32
+}
+
+func (s *stubLocator) Lookup(ip string) (*ip2location.Location, error) {
+	s.calls.Add(1)
+	if s.err != nil {
+		return nil, s.err
+	}
+	loc := *s.location
+	return &loc, nil
+}
+
+// newTestApp returns an App over the MaxMind and IP2Location stubs
+func newTestApp(t *testing.T, maxmind, ip2loc *stubLocator) *App {
+	t.Helper()
+	providers, err := ip2location.NewAggregator(ip2location.AggregatorConfig{},
+		ip2location.Source{Service: maxmind, Name: string(ip2location.MaxMindProvider), Priority: 1},
+		ip2location.Source{Service: ip2loc, Name: string(ip2location.IP2LocationProvider)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &App{providers: providers, ua: useragent.NewDefault(0)}
+}
+
+func newTestRules(t *testing.T, src string) *rules.Engine {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := rules.NewEngine(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return engine
+}
+
+func TestDecideWithoutLocation(t *testing.T) {
+	failing := &stubLocator{err: errors.New("database closed")}
+	a := newTestApp(t, failing, failing)
+	a.rules = newTestRules(t, `
+default: allow
+rules:
+  - name: sanctioned
+    when: has(location.country) && location.country.code == "KP"
+    decision: deny
+`)
+
+	if _, err := a.decide(context.Background(), "175.45.176.1", "", &DeviceInfo{}); !errors.Is(err, errNoLocation) {
+		t.Fatalf("decide() error = %v, want errNoLocation", err)
+	}
+
+	app := fiber.New()
+	app.Post("/v2/decide", useVersion(apiV2), a.handleDecide)
+	req := httptest.NewRequest("POST", "/v2/decide", strings.NewReader(`{"ip": "175.45.176.1", "user_agent": "curl/8.0"}`))
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != fiber.StatusServiceUnavailable || strings.Contains(string(body), `"allow"`) ||
+		!strings.Contains(string(body), "database closed") {
+		t.Errorf("got %d %s, want 503 with the provider failures", res.StatusCode, body)
+	}
+}
+
+func TestDecideRuleError(t *testing.T) {
+	located := &stubLocator{location: &ip2location.Location{Country: "United States", CountryCode: "US"}}
+	a := newTestApp(t, located, located)
+
+	tests := []struct {
+		name     string
+		src      string
+		decision string
+		rule     string
+	}{
+		{
+			// bots fails without a device.bot, so the allow after it must
+			// not stand
+			name: "allow",
+			src: `
+rules:
+  - {name: bots, when: 'device.bot.category == "scraper"', decision: deny}
+  - {name: everyone, when: 'true', decision: allow}`,
+			decision: rules.Review, rule: "bots",
+		},
+		{
+			name: "default",
+			src: `
+default: allow
+rules:
+  - {name: bots, when: 'device.bot.category == "scraper"', decision: deny}`,
+			decision: rules.Review, rule: "bots",
+		},
+		{
+			name: "deny",
+			src: `
+rules:
+  - {name: bots, when: 'device.bot.category == "scraper"', decision: deny}
+  - {name: us, when: 'location.country.code == "US"', decision: deny}`,
+			decision: rules.Deny, rule: "us",
+		},
+		{
+			name: "dry run",
+			src: `
+rules:
+  - {name: bots, when: 'device.bot.category == "scraper"', decision: deny, dry_run: true}`,
+			decision: rules.Allow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.rules = newTestRules(t, tt.src)
+			got, err := a.decide(context.Background(), "8.8.8.8", "", &DeviceInfo{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Decision != tt.decision || got.Rule != tt.rule {
+				t.Errorf("decide() = %+v, want %s by %q", got, tt.decision, tt.rule)
+			}
+		})
+	}
+}
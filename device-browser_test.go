@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/imnitish-dev/ip2location/ip2location"
+	pb "github.com/imnitish-dev/ip2location/proto"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"github.com/imnitish-dev/ip2location/useragent"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestGRPCDevice(t *testing.T) {
+	newApp := func() *App {
+		location := &ip2location.Location{Country: "United States", CountryCode: "US"}
+		return newTestApp(t, &stubLocator{location: location}, &stubLocator{location: location})
+	}
+	userAgentOnly := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", testChromeUA))
+	withMetadata := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"user-agent", testChromeUA,
+		"sec-ch-ua", `"Chromium";v="120", "Microsoft Edge";v="120"`,
+		"sec-ch-ua-platform", `"Windows"`,
+		"sec-ch-ua-platform-version", `"15.0.0"`,
+	))
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		userAgent string
+		// browser is "" when no device is expected
+		browser, os, version string
+	}{
+		{name: "field", ctx: context.Background(), userAgent: testIPhoneUA, browser: "Safari", os: "iOS", version: "17.2"},
+		{name: "metadata", ctx: userAgentOnly, browser: "Chrome", os: "Windows", version: "10"},
+		{name: "metadata with hints", ctx: withMetadata, browser: "Edge", os: "Windows", version: "11"},
+		// The field replaces the user-agent metadata
+		{name: "field and metadata", ctx: userAgentOnly, userAgent: testIPhoneUA, browser: "Safari", os: "iOS", version: "17.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &GRPCServer{app: newApp()}
+			res, err := server.LookupIP(tt.ctx, &pb.LookupRequest{Ip: "192.0.2.1", UserAgent: tt.userAgent})
+			if err != nil {
+				t.Fatal(err)
+			}
+			device := res.GetDevice()
+			if device.GetBrowser() != tt.browser || device.GetOs() != tt.os || device.GetVersion() != tt.version {
+				t.Errorf("LookupIP() device = %v, want %s on %s %s", device, tt.browser, tt.os, tt.version)
+			}
+
+			serverV2 := &GRPCServerV2{app: newApp()}
+			resV2, err := serverV2.LookupIP(tt.ctx, &pbv2.LookupRequest{Ip: "192.0.2.1", UserAgent: tt.userAgent})
+			if err != nil {
+				t.Fatal(err)
+			}
+			deviceV2 := resV2.GetDevice()
+			if deviceV2.GetBrowser().GetName() != tt.browser || deviceV2.GetOs().GetName() != tt.os || deviceV2.GetOs().GetVersion() != tt.version {
+				t.Errorf("v2 LookupIP() device = %v, want %s on %s %s", deviceV2, tt.browser, tt.os, tt.version)
+			}
+		})
+	}
+
+	t.Run("googlebot", func(t *testing.T) {
+		server := &GRPCServer{app: newApp()}
+		res, err := server.LookupIP(context.Background(), &pb.LookupRequest{Ip: "192.0.2.1", UserAgent: testGooglebot})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.GetDevice().GetIsBot() || res.GetDevice().GetBotName() != "Googlebot" {
+			t.Errorf("LookupIP() device = %v", res.GetDevice())
+		}
+	})
+
+	t.Run("masked out", func(t *testing.T) {
+		server := &GRPCServer{app: newApp()}
+		res, err := server.LookupIP(withMetadata, &pb.LookupRequest{
+			Ip:        "192.0.2.1",
+			FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"maxmind.country_code"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.GetDevice() != nil || res.GetMaxmind().GetCountryCode() != "US" {
+			t.Errorf("LookupIP() = %v", res)
+		}
+
+		serverV2 := &GRPCServerV2{app: newApp()}
+		resV2, err := serverV2.LookupIP(withMetadata, &pbv2.LookupRequest{
+			Ip:        "192.0.2.1",
+			FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"providers.maxmind.country"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resV2.GetDevice() != nil || resV2.GetProviders().GetMaxmind().GetCountry().GetCode() != "US" {
+			t.Errorf("v2 LookupIP() = %v", resV2)
+		}
+	})
+
+	// Without a user agent or hints the device is unknown, not missing
+	a := &App{ua: useragent.NewDefault(0)}
+	if info := a.grpcDevice(context.Background(), ""); info.OS != "Unknown" || info.IsBot {
+		t.Errorf("grpcDevice() = %+v", info)
+	}
+}
@@ -7,37 +7,36 @@ import (
 	"log"
 	"time"
 
-	pb "github.com/imnitish-dev/ip2location/proto"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"github.com/imnitish-dev/ip2location/client"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
 )
 
 func main() {
 	// Command line flags
-	ip := flag.String("ip", "", "IP address to lookup")
+	ip := flag.String("ip", "", "IP address or host name to lookup; the caller's own address when empty")
 	server := flag.String("server", "localhost:50051", "gRPC server address")
+	rest := flag.String("rest", "", "REST base URL, e.g. http://localhost:3000, to use instead of gRPC")
 	timeout := flag.Duration("timeout", 5*time.Second, "Timeout for request")
 	flag.Parse()
 
-	// Check if IP is provided
-	if *ip == "" {
-		log.Fatal("Please provide an IP address using -ip flag")
+	var (
+		c   client.Client
+		err error
+	)
+	if *rest != "" {
+		c, err = client.NewREST(*rest)
+	} else {
+		c, err = client.NewGRPC(*server)
 	}
-
-	// Connect to gRPC server
-	conn, err := grpc.Dial(*server, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
-		log.Fatalf("Failed to connect: %v", err)
+		log.Fatalf("Failed to create client: %v", err)
 	}
-	defer conn.Close()
+	defer c.Close()
 
-	// Create client
-	client := pb.NewIP2LocationServiceClient(conn)
 	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
 	defer cancel()
 
-	// Make the request
-	resp, err := client.LookupIP(ctx, &pb.LookupRequest{Ip: *ip})
+	resp, err := c.Lookup(ctx, *ip)
 	if err != nil {
 		log.Fatalf("Could not lookup IP: %v", err)
 	}
@@ -45,26 +44,20 @@ func main() {
 	// Print response in a formatted way
 	fmt.Println("\nIP Lookup Results:")
 	fmt.Println("==================")
-	
-	if resp.Message != "" {
-		fmt.Printf("Message: %s\n", resp.Message)
-	}
+	fmt.Printf("IP: %s\n", resp.Ip)
 
-	if resp.Maxmind != nil {
-		fmt.Println("\nMaxMind Data:")
-		fmt.Printf("  Country:      %s\n", resp.Maxmind.Country)
-		fmt.Printf("  City:         %s\n", resp.Maxmind.City)
-		fmt.Printf("  Region:       %s\n", resp.Maxmind.Region)
-		fmt.Printf("  Country Code: %s\n", resp.Maxmind.CountryCode)
-		fmt.Printf("  Coordinates:  %.6f, %.6f\n", resp.Maxmind.Latitude, resp.Maxmind.Longitude)
-	}
+	printLocation("MaxMind", resp.GetProviders().GetMaxmind())
+	printLocation("IP2Location", resp.GetProviders().GetIp2Location())
+}
 
-	if resp.Ip2Location != nil {
-		fmt.Println("\nIP2Location Data:")
-		fmt.Printf("  Country:      %s\n", resp.Ip2Location.Country)
-		fmt.Printf("  City:         %s\n", resp.Ip2Location.City)
-		fmt.Printf("  Region:       %s\n", resp.Ip2Location.Region)
-		fmt.Printf("  Country Code: %s\n", resp.Ip2Location.CountryCode)
-		fmt.Printf("  Coordinates:  %.6f, %.6f\n", resp.Ip2Location.Latitude, resp.Ip2Location.Longitude)
+func printLocation(provider string, loc *pbv2.Location) {
+	if loc == nil {
+		return
 	}
-} 
\ No newline at end of file
+	fmt.Printf("\n%s Data:\n", provider)
+	fmt.Printf("  Country:      %s (%s)\n", loc.GetCountry().GetName(), loc.GetCountry().GetCode())
+	fmt.Printf("  Region:       %s\n", loc.GetRegion().GetName())
+	fmt.Printf("  City:         %s\n", loc.City)
+	fmt.Printf("  Coordinates:  %.6f, %.6f\n", loc.GetCoordinates().GetLatitude(), loc.GetCoordinates().GetLongitude())
+	fmt.Printf("  Time Zone:    %s\n", loc.GetTimeZone().GetName())
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// fieldMask is a set of dotted field paths such as maxmind.country_code.
+// Selecting a field selects everything below it; an empty mask selects
+// everything.
+type fieldMask []string
+
+// wants reports whether path, or anything below it, is selected
+func (m fieldMask) wants(path string) bool {
+	if len(m) == 0 {
+		return true
+	}
+	for _, selected := range m {
+		if selected == path ||
+			strings.HasPrefix(path, selected+".") ||
+			strings.HasPrefix(selected, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// covers reports whether path and everything below it is selected
+func (m fieldMask) covers(path string) bool {
+	if len(m) == 0 {
+		return true
+	}
+	for _, selected := range m {
+		if selected == path || strings.HasPrefix(path, selected+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupWork says which parts of a lookup have to be computed
+type lookupWork struct {
+	maxmind     bool
+	ip2location bool
+	device      bool
+	// timeZone fills in time zones the providers do not report
+	timeZone bool
+}
+
+// work derives the lookups a mask needs. Provider fields may be selected
+// at the top level or, for host lookups, under addresses.
+func (m fieldMask) work(deviceField string) lookupWork {
+	return lookupWork{
+		maxmind:     m.wants("maxmind") || m.wants("addresses.maxmind"),
+		ip2location: m.wants("ip2location") || m.wants("addresses.ip2location"),
+		device:      m.wants(deviceField),
+	}
+}
+
+// workV2 derives the lookups a mask over ResponseV2 needs
+func (m fieldMask) workV2() lookupWork {
+	// The merged location needs every provider
+	merged := m.wants("location") || m.wants("addresses.location")
+	return lookupWork{
+		maxmind:     merged || m.wants("providers.maxmind") || m.wants("addresses.providers.maxmind"),
+		ip2location: merged || m.wants("providers.ip2location") || m.wants("addresses.providers.ip2location"),
+		device:      m.wants("device"),
+		timeZone: m.wants("location.time_zone") || m.wants("addresses.location.time_zone") ||
+			m.wants("providers.maxmind.time_zone") || m.wants("providers.ip2location.time_zone") ||
+			m.wants("addresses.providers.maxmind.time_zone") || m.wants("addresses.providers.ip2location.time_zone"),
+	}
+}
+
+// providers reports whether any provider lookup was requested
+func (w lookupWork) providers() bool {
+	return w.maxmind || w.ip2location
+}
+
+// parseFields parses a comma-separated ?fields= value, using the JSON names
+// of the response type of version
+func parseFields(s string, version *apiVersion) (fieldMask, error) {
+	var mask fieldMask
+	for _, path := range strings.Split(s, ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		if !validPath(version.response, path) {
+			return nil, fmt.Errorf("unknown field %q", path)
+		}
+		mask = append(mask, path)
+	}
+	return withField(mask, version.keep), nil
+}
+
+// withField keeps a field, such as the message that carries errors, in
+// every non-empty mask
+func withField(mask fieldMask, field string) fieldMask {
+	if len(mask) == 0 || mask.covers(field) {
+		return mask
+	}
+	return append(mask, field)
+}
+
+// validPath reports whether a dotted path names a JSON field of t
+func validPath(t reflect.Type, path string) bool {
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+
+		found := false
+		for _, field := range jsonFields(t) {
+			if field.name == name {
+				t = t.FieldByIndex(field.index).Type
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldsOf returns the request's ?fields= selection, parsed once per request
+func fieldsOf(c *fiber.Ctx) (fieldMask, error) {
+	if mask, ok := c.Locals("fields").(fieldMask); ok {
+		return mask, nil
+	}
+
+	mask, err := parseFields(c.Query("fields"), versionOf(c))
+	if err != nil {
+		return nil, err
+	}
+	c.Locals("fields", mask)
+	return mask, nil
+}
+
+// requestWork parses ?fields= and returns the lookups it needs
+func requestWork(c *fiber.Ctx) (lookupWork, error) {
+	mask, err := fieldsOf(c)
+	if err != nil {
+		return lookupWork{}, err
+	}
+	if versionOf(c) == apiV2 {
+		return mask.workV2(), nil
+	}
+	return mask.work("deviceBrowser"), nil
+}
+
+// object is a JSON object that keeps its members in field order
+type object []member
+
+type member struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON implements json.Marshaler
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder
+func (o object) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeMapLen(len(o)); err != nil {
+		return err
+	}
+	for _, m := range o {
+		if err := enc.EncodeString(m.key); err != nil {
+			return err
+		}
+		if err := enc.Encode(m.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shape copies the selected fields of v into objects, keeping the JSON
+// names and omitempty behaviour
+func shape(v reflect.Value, mask fieldMask, prefix string) interface{} {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		obj := object{}
+		for _, field := range jsonFields(v.Type()) {
+			path := field.name
+			if prefix != "" {
+				path = prefix + "." + field.name
+			}
+			if !mask.wants(path) {
+				continue
+			}
+			fv := v.FieldByIndex(field.index)
+			if field.omitEmpty && fv.IsZero() {
+				continue
+			}
+			obj = append(obj, member{field.name, shape(fv, mask, path)})
+		}
+		return obj
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = shape(v.Index(i), mask, prefix)
+		}
+		return items
+	default:
+		return v.Interface()
+	}
+}
+
+// prune zeroes the fields of v that mask does not select, for formats that
+// drop zero values on their own
+func prune(v reflect.Value, mask fieldMask, prefix string) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, field := range jsonFields(v.Type()) {
+			path := field.name
+			if prefix != "" {
+				path = prefix + "." + field.name
+			}
+			fv := v.FieldByIndex(field.index)
+			switch {
+			case !mask.wants(path):
+				fv.SetZero()
+			case !mask.covers(path):
+				prune(fv, mask, path)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			prune(v.Index(i), mask, prefix)
+		}
+	}
+}
+
+// protoFieldMask converts a gRPC FieldMask to a fieldMask after checking its
+// paths against msg, keeping the field that carries errors. Unlike
+// fieldmaskpb's own validation, paths may descend into repeated messages,
+// as in addresses.maxmind.city.
+func protoFieldMask(fm *fieldmaskpb.FieldMask, msg protoreflect.Message, keep string) (fieldMask, error) {
+	var mask fieldMask
+	for _, path := range fm.GetPaths() {
+		desc := msg.Descriptor()
+		for _, name := range strings.Split(path, ".") {
+			if desc == nil {
+				return nil, fmt.Errorf("unknown field %q", path)
+			}
+			field := desc.Fields().ByName(protoreflect.Name(name))
+			if field == nil {
+				return nil, fmt.Errorf("unknown field %q", path)
+			}
+			desc = nil
+			if field.Message() != nil && !field.IsMap() {
+				desc = field.Message()
+			}
+		}
+		mask = append(mask, path)
+	}
+	return withField(mask, keep), nil
+}
+
+// pruneProto clears the fields of msg that mask does not select
+func pruneProto(msg protoreflect.Message, mask fieldMask, prefix string) {
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		path := string(field.Name())
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		switch {
+		case !mask.wants(path):
+			msg.Clear(field)
+		case mask.covers(path) || field.Message() == nil || field.IsMap():
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				pruneProto(list.Get(i).Message(), mask, path)
+			}
+		default:
+			pruneProto(value.Message(), mask, path)
+		}
+		return true
+	})
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/ip2location"
+	pb "github.com/imnitish-dev/ip2location/proto"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestFieldMaskWants(t *testing.T) {
+	mask := fieldMask{"maxmind.city", "ip"}
+	tests := []struct {
+		path          string
+		wants, covers bool
+	}{
+		{"ip", true, true},
+		{"maxmind", true, false},
+		{"maxmind.city", true, true},
+		{"maxmind.country", false, false},
+		{"ip2location", false, false},
+		// A prefix of a name is not its parent
+		{"i", false, false},
+		{"maxmind.cit", false, false},
+	}
+	for _, tt := range tests {
+		if got := mask.wants(tt.path); got != tt.wants {
+			t.Errorf("wants(%q) = %v, want %v", tt.path, got, tt.wants)
+		}
+		if got := mask.covers(tt.path); got != tt.covers {
+			t.Errorf("covers(%q) = %v, want %v", tt.path, got, tt.covers)
+		}
+	}
+
+	// Selecting a field selects everything below it
+	if !(fieldMask{"maxmind"}).covers("maxmind.city.name") {
+		t.Error("covers() missed a descendant")
+	}
+	// The empty mask selects everything
+	if !(fieldMask{}).wants("anything") || !(fieldMask(nil)).covers("anything") {
+		t.Error("the empty mask does not select everything")
+	}
+}
+
+func TestFieldMaskWork(t *testing.T) {
+	all := lookupWork{maxmind: true, ip2location: true, device: true}
+	tests := []struct {
+		mask fieldMask
+		want lookupWork
+	}{
+		{nil, all},
+		{fieldMask{"message"}, lookupWork{}},
+		{fieldMask{"ip", "message"}, lookupWork{}},
+		{fieldMask{"maxmind.city", "message"}, lookupWork{maxmind: true}},
+		{fieldMask{"ip2location"}, lookupWork{ip2location: true}},
+		{fieldMask{"addresses.maxmind.country_code"}, lookupWork{maxmind: true}},
+		{fieldMask{"addresses"}, lookupWork{maxmind: true, ip2location: true}},
+		{fieldMask{"deviceBrowser.os"}, lookupWork{device: true}},
+	}
+	for _, tt := range tests {
+		if got := tt.mask.work("deviceBrowser"); got != tt.want {
+			t.Errorf("%v.work() = %+v, want %+v", tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestFieldMaskWorkV2(t *testing.T) {
+	all := lookupWork{maxmind: true, ip2location: true, device: true, timeZone: true}
+	tests := []struct {
+		mask fieldMask
+		want lookupWork
+	}{
+		{nil, all},
+		{fieldMask{"error"}, lookupWork{}},
+		{fieldMask{"providers.maxmind.city", "error"}, lookupWork{maxmind: true}},
+		{fieldMask{"providers.ip2location"}, lookupWork{ip2location: true, timeZone: true}},
+		{fieldMask{"providers.ip2location.country"}, lookupWork{ip2location: true}},
+		// The merged location needs every provider
+		{fieldMask{"location.city"}, lookupWork{maxmind: true, ip2location: true}},
+		{fieldMask{"location.time_zone.name"}, lookupWork{maxmind: true, ip2location: true, timeZone: true}},
+		{fieldMask{"addresses.providers.maxmind"}, lookupWork{maxmind: true, timeZone: true}},
+		{fieldMask{"addresses.location.coordinates"}, lookupWork{maxmind: true, ip2location: true}},
+		{fieldMask{"device.os"}, lookupWork{device: true}},
+	}
+	for _, tt := range tests {
+		if got := tt.mask.workV2(); got != tt.want {
+			t.Errorf("%v.workV2() = %+v, want %+v", tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	mask, err := parseFields(" ip, maxmind.city ,,", apiV1)
+	if err != nil || !reflect.DeepEqual(mask, fieldMask{"ip", "maxmind.city", "message"}) {
+		t.Errorf("parseFields() = %v, %v", mask, err)
+	}
+	// The error field is not added twice, nor to the empty mask
+	if mask, _ := parseFields("error", apiV2); !reflect.DeepEqual(mask, fieldMask{"error"}) {
+		t.Errorf("parseFields(error) = %v", mask)
+	}
+	if mask, _ := parseFields("", apiV2); mask != nil {
+		t.Errorf("parseFields(\"\") = %v", mask)
+	}
+
+	for _, fields := range []string{"maxmind.nope", "providers", "ip.value", "maxmind.city.name"} {
+		if _, err := parseFields(fields, apiV1); err == nil {
+			t.Errorf("parseFields(%q) succeeded on v1", fields)
+		}
+	}
+	if _, err := parseFields("providers.maxmind.region.code,addresses.location", apiV2); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestShapeAndPrune(t *testing.T) {
+	mask := fieldMask{"ip", "maxmind.city", "maxmind.latitude"}
+
+	data, err := json.Marshal(shape(reflect.ValueOf(testResponse), mask, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fields keep their order and omitempty behaviour, and zero values
+	// that are not omitempty are kept
+	want := `{"maxmind":{"city":"Mountain View","latitude":37.4},"ip":"192.0.2.1"}`
+	if string(data) != want {
+		t.Errorf("shape() = %s, want %s", data, want)
+	}
+
+	response := testResponse
+	response.MaxMind = &ip2location.Location{}
+	*response.MaxMind = *testResponse.MaxMind
+	response.Host = "example.com"
+	prune(reflect.ValueOf(&response), mask, "")
+	if response.Host != "" || response.Ip != "192.0.2.1" ||
+		*response.MaxMind != (ip2location.Location{City: "Mountain View", Latitude: 37.4}) {
+		t.Errorf("prune() = %+v, %+v", response, *response.MaxMind)
+	}
+	// The original is untouched
+	if testResponse.MaxMind.CountryCode != "US" {
+		t.Error("prune() changed a shared location")
+	}
+}
+
+func TestProtoFieldMask(t *testing.T) {
+	msg := (&pb.LookupResponse{}).ProtoReflect()
+	mask, err := protoFieldMask(&fieldmaskpb.FieldMask{Paths: []string{"ip", "addresses.maxmind.city"}}, msg, "message")
+	if err != nil || !reflect.DeepEqual(mask, fieldMask{"ip", "addresses.maxmind.city", "message"}) {
+		t.Errorf("protoFieldMask() = %v, %v", mask, err)
+	}
+	if mask, err := protoFieldMask(nil, msg, "message"); err != nil || mask != nil {
+		t.Errorf("protoFieldMask(nil) = %v, %v", mask, err)
+	}
+	for _, path := range []string{"nope", "maxmind.nope", "ip.value", "maxmind.city.name"} {
+		if _, err := protoFieldMask(&fieldmaskpb.FieldMask{Paths: []string{path}}, msg, "message"); err == nil {
+			t.Errorf("protoFieldMask(%q) succeeded", path)
+		}
+	}
+
+	response := &pb.LookupResponse{
+		Ip:          "192.0.2.1",
+		Maxmind:     &pb.Location{City: "Mountain View", CountryCode: "US"},
+		Ip2Location: &pb.Location{City: "Mountain View"},
+		Addresses: []*pb.ResolvedAddress{
+			{Ip: "192.0.2.1", Maxmind: &pb.Location{City: "Mountain View", CountryCode: "US"}},
+		},
+	}
+	pruneProto(response.ProtoReflect(), fieldMask{"maxmind.city", "addresses.maxmind.country_code"}, "")
+	want := &pb.LookupResponse{
+		Maxmind:   &pb.Location{City: "Mountain View"},
+		Addresses: []*pb.ResolvedAddress{{Maxmind: &pb.Location{CountryCode: "US"}}},
+	}
+	if !proto.Equal(response, want) {
+		t.Errorf("pruneProto() = %v, want %v", response, want)
+	}
+}
+
+// TestFieldsSkipLookups checks that providers whose fields are not selected
+// are never asked
+func TestFieldsSkipLookups(t *testing.T) {
+	newStubs := func() (*stubLocator, *stubLocator) {
+		return &stubLocator{location: &ip2location.Location{Country: "United States", CountryCode: "US", City: "Mountain View"}},
+			&stubLocator{location: &ip2location.Location{Country: "United States of America", CountryCode: "US", City: "Mountain View"}}
+	}
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"/v1/lookup/192.0.2.1?fields=maxmind.city", `{"maxmind":{"city":"Mountain View"}}`},
+		{"/v2/lookup/192.0.2.1?fields=providers.maxmind.city", `{"providers":{"maxmind":{"city":"Mountain View"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			maxmind, ip2loc := newStubs()
+			a := newTestApp(t, maxmind, ip2loc)
+			app := fiber.New()
+			app.Get("/v1/lookup/:ip", useVersion(apiV1), a.handleIPLookup)
+			app.Get("/v2/lookup/:ip", useVersion(apiV2), a.handleIPLookup)
+
+			res, err := app.Test(httptest.NewRequest("GET", tt.target, nil), -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if strings.TrimSpace(string(body)) != tt.want {
+				t.Errorf("got %s, want %s", body, tt.want)
+			}
+			if maxmind.calls.Load() != 1 || ip2loc.calls.Load() != 0 {
+				t.Errorf("MaxMind was asked %d times and IP2Location %d, want 1 and 0", maxmind.calls.Load(), ip2loc.calls.Load())
+			}
+		})
+	}
+
+	t.Run("grpc", func(t *testing.T) {
+		maxmind, ip2loc := newStubs()
+		server := &GRPCServer{app: newTestApp(t, maxmind, ip2loc)}
+		res, err := server.LookupIP(context.Background(), &pb.LookupRequest{
+			Ip:        "192.0.2.1",
+			FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"maxmind.city"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(res, &pb.LookupResponse{Maxmind: &pb.Location{City: "Mountain View"}}) {
+			t.Errorf("LookupIP() = %v", res)
+		}
+		if maxmind.calls.Load() != 1 || ip2loc.calls.Load() != 0 {
+			t.Errorf("MaxMind was asked %d times and IP2Location %d, want 1 and 0", maxmind.calls.Load(), ip2loc.calls.Load())
+		}
+	})
+
+	t.Run("grpc v2", func(t *testing.T) {
+		maxmind, ip2loc := newStubs()
+		server := &GRPCServerV2{app: newTestApp(t, maxmind, ip2loc)}
+		res, err := server.LookupIP(context.Background(), &pbv2.LookupRequest{
+			Ip:        "192.0.2.1",
+			FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"providers.ip2location.city"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.GetProviders().GetIp2Location().GetCity() != "Mountain View" || res.GetProviders().GetMaxmind() != nil {
+			t.Errorf("LookupIP() = %v", res)
+		}
+		if maxmind.calls.Load() != 0 || ip2loc.calls.Load() != 1 {
+			t.Errorf("MaxMind was asked %d times and IP2Location %d, want 0 and 1", maxmind.calls.Load(), ip2loc.calls.Load())
+		}
+	})
+}
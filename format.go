@@ -0,0 +1,486 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	pb "github.com/imnitish-dev/ip2location/proto"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Output formats, selected with ?format= or the Accept header
+const (
+	FormatJSON     = "json"
+	FormatXML      = "xml"
+	FormatCSV      = "csv"
+	FormatMsgPack  = "msgpack"
+	FormatProtobuf = "protobuf"
+	FormatGeoJSON  = "geojson"
+)
+
+// formatTypes maps each format to the media types it answers to; the first
+// one is sent as Content-Type
+var formatTypes = map[string][]string{
+	FormatJSON:     {fiber.MIMEApplicationJSON},
+	FormatXML:      {fiber.MIMEApplicationXML, fiber.MIMETextXML},
+	FormatCSV:      {"text/csv"},
+	FormatMsgPack:  {"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"},
+	FormatProtobuf: {"application/x-protobuf", "application/protobuf", "application/vnd.google.protobuf"},
+	FormatGeoJSON:  {"application/geo+json"},
+}
+
+// formatOffers lists every media type in order of preference for Accept
+// negotiation; JSON comes first so that */* and a missing header get JSON
+var formatOffers, formatByType = func() ([]string, map[string]string) {
+	var (
+		offers []string
+		byType = make(map[string]string)
+	)
+	for _, format := range []string{FormatJSON, FormatXML, FormatCSV, FormatMsgPack, FormatProtobuf, FormatGeoJSON} {
+		for _, mediaType := range formatTypes[format] {
+			offers = append(offers, mediaType)
+			byType[mediaType] = format
+		}
+	}
+	return offers, byType
+}()
+
+// negotiateFormat picks the output format. An explicit ?format= wins over
+// Accept; Accept headers that match nothing fall back to JSON.
+func negotiateFormat(c *fiber.Ctx) (string, error) {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		if _, ok := formatTypes[format]; !ok {
+			return "", fmt.Errorf("unsupported format %q", format)
+		}
+		return format, nil
+	}
+
+	if format, ok := formatByType[c.Accepts(formatOffers...)]; ok {
+		return format, nil
+	}
+	return FormatJSON, nil
+}
+
+// render writes v, a Response or a []Response, in the negotiated format.
+// On /v2 routes it is converted to ResponseV2 first.
+func render(c *fiber.Ctx, v interface{}) error {
+	format, err := negotiateFormat(c)
+	if err != nil {
+		return writeError(c, fiber.StatusNotAcceptable, err.Error())
+	}
+
+	mask, err := fieldsOf(c)
+	if err != nil {
+		return writeError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if versionOf(c) == apiV2 {
+		v = toV2Value(v, c.Response().StatusCode())
+	}
+
+	// Formats that write every field get a shaped copy holding only the
+	// selected ones. Protobuf drops zero values, so pruning is enough, and
+	// CSV and GeoJSON select their columns and properties themselves.
+	shaped := v
+	switch {
+	case len(mask) == 0 || format == FormatCSV || format == FormatGeoJSON:
+	case format == FormatProtobuf:
+		pruned := reflect.New(reflect.TypeOf(v))
+		pruned.Elem().Set(reflect.ValueOf(v))
+		prune(pruned, mask, "")
+		v = pruned.Elem().Interface()
+	default:
+		shaped = shape(reflect.ValueOf(v), mask, "")
+	}
+
+	c.Vary(fiber.HeaderAccept)
+	if format == FormatJSON {
+		return c.JSON(shaped)
+	}
+
+	var body []byte
+	switch format {
+	case FormatXML:
+		body, err = encodeXML(shaped)
+	case FormatCSV:
+		body, err = encodeCSV(v, mask)
+	case FormatMsgPack:
+		body, err = encodeMsgPack(shaped)
+	case FormatProtobuf:
+		body, err = proto.Marshal(toPBMessage(v))
+	case FormatGeoJSON:
+		body, err = encodeGeoJSON(v, mask)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, formatTypes[format][0])
+	return c.Send(body)
+}
+
+func encodeMsgPack(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	// Share field names with the JSON output
+	enc.SetCustomStructTag("json")
+	enc.SetOmitEmpty(true)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeXML writes v with the JSON field names, as <response> or as
+// <responses> wrapping one <response> per result
+func encodeXML(v interface{}) ([]byte, error) {
+	buf := bytes.NewBufferString(xml.Header)
+	enc := xml.NewEncoder(buf)
+
+	rv := reflect.ValueOf(v)
+	if _, ok := v.(object); !ok && rv.Kind() == reflect.Slice {
+		start := xml.StartElement{Name: xml.Name{Local: "responses"}}
+		if err := enc.EncodeToken(start); err != nil {
+			return nil, err
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := writeXML(enc, "response", rv.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		if err := enc.EncodeToken(start.End()); err != nil {
+			return nil, err
+		}
+	} else if err := writeXML(enc, "response", rv); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeXML(enc *xml.Encoder, name string, v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if obj, ok := v.Interface().(object); ok {
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, m := range obj {
+			if err := writeXML(enc, m.key, reflect.ValueOf(m.value)); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, field := range jsonFields(v.Type()) {
+			fv := v.FieldByIndex(field.index)
+			if field.omitEmpty && fv.IsZero() {
+				continue
+			}
+			if err := writeXML(enc, field.name, fv); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	case reflect.Slice:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := writeXML(enc, singular(name), v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	default:
+		return enc.EncodeElement(scalar(v), start)
+	}
+}
+
+// singular names the elements of a list, e.g. addresses -> address or
+// currencies -> currency
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return "item"
+}
+
+// encodeCSV writes one row per response with the columns mask selects.
+// Nested objects become dotted columns such as maxmind.country_code, and
+// host lookups are expanded to one row per resolved address.
+func encodeCSV(v interface{}, mask fieldMask) ([]byte, error) {
+	var rows []interface{}
+	switch v := v.(type) {
+	case Response:
+		for _, response := range expandAddresses(v) {
+			rows = append(rows, response)
+		}
+	case []Response:
+		for _, result := range v {
+			for _, response := range expandAddresses(result) {
+				rows = append(rows, response)
+			}
+		}
+	case ResponseV2:
+		for _, response := range expandAddressesV2(v) {
+			rows = append(rows, response)
+		}
+	case []ResponseV2:
+		for _, result := range v {
+			for _, response := range expandAddressesV2(result) {
+				rows = append(rows, response)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("csv output is not supported for %T", v)
+	}
+
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	var columns []csvColumn
+	for _, column := range csvColumns(t, "", nil) {
+		// Address rows carry the address in ip and its results at the top level
+		if mask.wants(column.name) || mask.wants("addresses."+column.name) {
+			columns = append(columns, column)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	row := make([]string, len(columns))
+	for _, response := range rows {
+		rv := reflect.ValueOf(response)
+		for i, column := range columns {
+			row[i] = ""
+			if fv, ok := fieldByIndex(rv, column.index); ok {
+				row[i] = scalar(fv)
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// expandAddresses turns a host lookup into one response per address
+func expandAddresses(response Response) []Response {
+	if len(response.Addresses) == 0 {
+		return []Response{response}
+	}
+
+	responses := make([]Response, len(response.Addresses))
+	for i, address := range response.Addresses {
+		responses[i] = Response{
+			Message:       response.Message,
+			MaxMind:       address.MaxMind,
+			IP2Location:   address.IP2Location,
+			DeviceBrowser: response.DeviceBrowser,
+			Ip:            address.IP,
+			Host:          response.Host,
+		}
+	}
+	return responses
+}
+
+type jsonField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// jsonFields lists the exported fields of a struct type under their JSON
+// names, skipping those tagged "-"
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{
+			name:      name,
+			index:     f.Index,
+			omitEmpty: strings.Contains(opts, "omitempty"),
+		})
+	}
+	return fields
+}
+
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// csvColumns flattens a struct type into dotted columns. Lists have no
+// tabular form and are left out.
+func csvColumns(t reflect.Type, prefix string, index []int) []csvColumn {
+	var columns []csvColumn
+	for _, field := range jsonFields(t) {
+		ft := t.FieldByIndex(field.index).Type
+		path := append(append([]int(nil), index...), field.index...)
+		name := prefix + field.name
+
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		switch ft.Kind() {
+		case reflect.Struct:
+			columns = append(columns, csvColumns(ft, name+".", path)...)
+		case reflect.Slice, reflect.Map:
+		default:
+			columns = append(columns, csvColumn{name: name, index: path})
+		}
+	}
+	return columns
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex, but reports false instead of
+// panicking on nil pointers along the path
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// scalar formats a leaf value for XML and CSV
+func scalar(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// toPBMessage converts a Response or a []Response, or their /v2 forms, to
+// the protobuf messages the gRPC services return
+func toPBMessage(v interface{}) proto.Message {
+	switch v := v.(type) {
+	case Response:
+		return toPBResponse(&v)
+	case []Response:
+		batch := &pb.LookupBatchResponse{Results: make([]*pb.LookupResponse, len(v))}
+		for i := range v {
+			batch.Results[i] = toPBResponse(&v[i])
+		}
+		return batch
+	case ResponseV2:
+		return toPBResponseV2(&v)
+	case []ResponseV2:
+		batch := &pbv2.LookupBatchResponse{Results: make([]*pbv2.LookupResponse, len(v))}
+		for i := range v {
+			batch.Results[i] = toPBResponseV2(&v[i])
+		}
+		return batch
+	}
+	return &pb.LookupResponse{Message: fmt.Sprintf("protobuf output is not supported for %T", v)}
+}
+
+// toPBResponse converts a REST response to a LookupResponse
+func toPBResponse(response *Response) *pb.LookupResponse {
+	out := &pb.LookupResponse{
+		Message:     response.Message,
+		Maxmind:     toPBLocation(response.MaxMind),
+		Ip2Location: toPBLocation(response.IP2Location),
+		Ip:          response.Ip,
+		Host:        response.Host,
+		Addresses:   toPBAddresses(response.Addresses),
+	}
+	if response.DeviceBrowser.OS != "" {
+		out.Device = toPBDeviceInfo(&response.DeviceBrowser)
+	}
+	return out
+}
+
+// toPBAddresses converts the addresses of a host lookup
+func toPBAddresses(addresses []ResolvedAddress) []*pb.ResolvedAddress {
+	var out []*pb.ResolvedAddress
+	for _, address := range addresses {
+		out = append(out, &pb.ResolvedAddress{
+			Ip:          address.IP,
+			Type:        address.Type,
+			Ttl:         address.TTL,
+			Maxmind:     toPBLocation(address.MaxMind),
+			Ip2Location: toPBLocation(address.IP2Location),
+		})
+	}
+	return out
+}
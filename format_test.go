@@ -0,0 +1,324 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/ip2location"
+	pb "github.com/imnitish-dev/ip2location/proto"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+var testResponse = Response{
+	Ip: "192.0.2.1",
+	MaxMind: &ip2location.Location{
+		Country: "United States", CountryCode: "US", City: "Mountain View",
+		Latitude: 37.4, Longitude: -122.1,
+	},
+}
+
+// newFormatApp serves testResponse at /v1/ok and /v2/ok, and a 400 at
+// /v1/fail and /v2/fail
+func newFormatApp() *fiber.App {
+	app := fiber.New()
+	for _, version := range []*apiVersion{apiV1, apiV2} {
+		group := app.Group("/"+version.name, useVersion(version))
+		group.Get("/ok", func(c *fiber.Ctx) error { return render(c, testResponse) })
+		group.Get("/fail", func(c *fiber.Ctx) error {
+			return render(c.Status(fiber.StatusBadRequest), Response{Message: "bad input"})
+		})
+	}
+	return app
+}
+
+func get(t *testing.T, app *fiber.App, target, accept string) (*http.Response, []byte) {
+	t.Helper()
+	req := httptest.NewRequest("GET", target, nil)
+	if accept != "" {
+		req.Header.Set(fiber.HeaderAccept, accept)
+	}
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res, body
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	app := newFormatApp()
+	tests := []struct {
+		name, target, accept string
+		status               int
+		contentType          string
+	}{
+		{name: "no header", target: "/v1/ok", status: 200, contentType: fiber.MIMEApplicationJSON},
+		{name: "any", target: "/v1/ok", accept: "*/*", status: 200, contentType: fiber.MIMEApplicationJSON},
+		{name: "xml", target: "/v1/ok", accept: "text/xml", status: 200, contentType: fiber.MIMEApplicationXML},
+		{name: "csv", target: "/v1/ok", accept: "text/csv", status: 200, contentType: "text/csv"},
+		{name: "msgpack", target: "/v1/ok", accept: "application/x-msgpack", status: 200, contentType: "application/msgpack"},
+		{name: "protobuf", target: "/v1/ok", accept: "application/protobuf", status: 200, contentType: "application/x-protobuf"},
+		{name: "geojson", target: "/v1/ok", accept: "application/geo+json", status: 200, contentType: "application/geo+json"},
+		{
+			name: "q-values", target: "/v1/ok", accept: "application/json;q=0.2, text/csv;q=0.5, application/xml;q=0.9",
+			status: 200, contentType: fiber.MIMEApplicationXML,
+		},
+		{name: "excluded", target: "/v1/ok", accept: "text/csv;q=0, application/msgpack", status: 200, contentType: "application/msgpack"},
+		// Accept headers that match nothing fall back to JSON
+		{name: "unmatched", target: "/v1/ok", accept: "image/png", status: 200, contentType: fiber.MIMEApplicationJSON},
+		{name: "query", target: "/v1/ok?format=csv", accept: "application/xml", status: 200, contentType: "text/csv"},
+		{name: "query case", target: "/v1/ok?format=MsgPack", status: 200, contentType: "application/msgpack"},
+		{name: "unknown query", target: "/v1/ok?format=yaml", status: 406, contentType: fiber.MIMEApplicationJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, body := get(t, app, tt.target, tt.accept)
+			if res.StatusCode != tt.status || !strings.HasPrefix(res.Header.Get(fiber.HeaderContentType), tt.contentType) {
+				t.Errorf("got %d %s, want %d %s: %s", res.StatusCode, res.Header.Get(fiber.HeaderContentType), tt.status, tt.contentType, body)
+			}
+			if res.StatusCode == 200 && res.Header.Get(fiber.HeaderVary) != fiber.HeaderAccept {
+				t.Errorf("Vary = %q, want Accept", res.Header.Get(fiber.HeaderVary))
+			}
+		})
+	}
+
+	res, body := get(t, app, "/v2/ok?format=yaml", "")
+	if res.StatusCode != fiber.StatusNotAcceptable || !strings.Contains(string(body), `"code":"not_acceptable"`) {
+		t.Errorf("/v2 unknown format: got %d %s, want 406 not_acceptable", res.StatusCode, body)
+	}
+}
+
+func TestRenderFormats(t *testing.T) {
+	app := newFormatApp()
+
+	t.Run("xml", func(t *testing.T) {
+		_, body := get(t, app, "/v1/ok?format=xml", "")
+		var got struct {
+			XMLName xml.Name `xml:"response"`
+			IP      string   `xml:"ip"`
+			MaxMind struct {
+				CountryCode string  `xml:"country_code"`
+				Latitude    float64 `xml:"latitude"`
+			} `xml:"maxmind"`
+		}
+		if err := xml.Unmarshal(body, &got); err != nil {
+			t.Fatalf("%v: %s", err, body)
+		}
+		if got.IP != "192.0.2.1" || got.MaxMind.CountryCode != "US" || got.MaxMind.Latitude != 37.4 {
+			t.Errorf("decoded %+v from %s", got, body)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		_, body := get(t, app, "/v1/ok?format=csv&fields=ip,maxmind.city,maxmind.latitude", "")
+		want := "message,maxmind.city,maxmind.latitude,ip\n,Mountain View,37.4,192.0.2.1\n"
+		if string(body) != want {
+			t.Errorf("got %q, want %q", body, want)
+		}
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		_, body := get(t, app, "/v1/ok?format=msgpack", "")
+		var got map[string]interface{}
+		if err := msgpack.Unmarshal(body, &got); err != nil {
+			t.Fatal(err)
+		}
+		maxmind, _ := got["maxmind"].(map[string]interface{})
+		if got["ip"] != "192.0.2.1" || maxmind["country_code"] != "US" {
+			t.Errorf("decoded %v", got)
+		}
+		// Empty fields are left out, as in JSON
+		if _, ok := got["message"]; ok {
+			t.Errorf("decoded an empty message: %v", got)
+		}
+	})
+
+	t.Run("protobuf", func(t *testing.T) {
+		_, body := get(t, app, "/v1/ok?format=protobuf", "")
+		var got pb.LookupResponse
+		if err := proto.Unmarshal(body, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Ip != "192.0.2.1" || got.Maxmind.GetCountryCode() != "US" {
+			t.Errorf("decoded %v", &got)
+		}
+	})
+
+	t.Run("protobuf v2", func(t *testing.T) {
+		_, body := get(t, app, "/v2/ok?format=protobuf", "")
+		var got pbv2.LookupResponse
+		if err := proto.Unmarshal(body, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Ip != "192.0.2.1" || got.Providers.GetMaxmind().GetCountry().GetCode() != "US" {
+			t.Errorf("decoded %v", &got)
+		}
+	})
+}
+
+// TestRenderErrors checks that errors are sent in the negotiated format with
+// the error code of their status
+func TestRenderErrors(t *testing.T) {
+	app := newFormatApp()
+
+	tests := []struct {
+		format string
+		decode func([]byte) (code, message string, err error)
+	}{
+		{"json", func(body []byte) (string, string, error) {
+			var got ResponseV2
+			err := json.Unmarshal(body, &got)
+			if got.Error == nil {
+				return "", "", err
+			}
+			return got.Error.Code, got.Error.Message, err
+		}},
+		{"xml", func(body []byte) (string, string, error) {
+			var got struct {
+				Code    string `xml:"error>code"`
+				Message string `xml:"error>message"`
+			}
+			err := xml.Unmarshal(body, &got)
+			return got.Code, got.Message, err
+		}},
+		{"csv", func(body []byte) (string, string, error) {
+			lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+			if len(lines) != 2 || lines[0] != "error.code,error.message" {
+				return "", "", errors.New("unexpected rows")
+			}
+			code, message, _ := strings.Cut(lines[1], ",")
+			return code, message, nil
+		}},
+		{"msgpack", func(body []byte) (string, string, error) {
+			var got struct {
+				Error struct {
+					Code    string `msgpack:"code"`
+					Message string `msgpack:"message"`
+				} `msgpack:"error"`
+			}
+			err := msgpack.Unmarshal(body, &got)
+			return got.Error.Code, got.Error.Message, err
+		}},
+		{"protobuf", func(body []byte) (string, string, error) {
+			var got pbv2.LookupResponse
+			err := proto.Unmarshal(body, &got)
+			return got.Error.GetCode(), got.Error.GetMessage(), err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			target := "/v2/fail?format=" + tt.format
+			if tt.format == "csv" {
+				target += "&fields=error"
+			}
+			res, body := get(t, app, target, "")
+			if res.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("status = %d, want 400", res.StatusCode)
+			}
+			code, message, err := tt.decode(body)
+			if err != nil || code != "invalid_request" || message != "bad input" {
+				t.Errorf("decoded %q, %q, %v from %q; want invalid_request", code, message, err, body)
+			}
+		})
+	}
+
+	// v1 errors carry only the message
+	_, body := get(t, app, "/v1/fail?format=xml", "")
+	if !strings.Contains(string(body), "<response><message>bad input</message></response>") {
+		t.Errorf("v1 xml error = %s", body)
+	}
+}
+
+func TestBatchLookup(t *testing.T) {
+	located := &stubLocator{location: &ip2location.Location{Country: "Germany", CountryCode: "DE"}}
+	a := newTestApp(t, located, located)
+	app := fiber.New()
+	app.Post("/v1/lookup", useVersion(apiV1), a.handleBatchLookup)
+	app.Post("/v2/lookup", useVersion(apiV2), a.handleBatchLookup)
+
+	post := func(target, body string) (int, []byte) {
+		t.Helper()
+		res, err := app.Test(httptest.NewRequest("POST", target, strings.NewReader(body)), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(res.Body)
+		return res.StatusCode, data
+	}
+
+	// Entries are not percent-decoded like path segments, so a zone ID is
+	// kept as is and an escape is not undone
+	status, body := post("/v2/lookup", `["192.0.2.1", "fe80::1%eth0", "not an ip", "010.0.0.1", "192.0.2.1%2F8"]`)
+	var results []ResponseV2
+	if err := json.Unmarshal(body, &results); err != nil || status != fiber.StatusOK || len(results) != 5 {
+		t.Fatalf("got %d %s, want five results", status, body)
+	}
+	for i, ip := range []string{"192.0.2.1", "fe80::1"} {
+		if loc := results[i].Location; loc == nil || loc.Country == nil || loc.Country.Code != "DE" || results[i].IP != ip || results[i].Error != nil {
+			t.Errorf("results[%d] = %+v, want %s in DE", i, results[i], ip)
+		}
+	}
+	for _, result := range results[2:] {
+		if result.Error == nil || result.Error.Code != "invalid_request" {
+			t.Errorf("invalid entry got %+v, want invalid_request", result.Error)
+		}
+	}
+
+	// Whole-request failures
+	tooMany := "[" + strings.TrimSuffix(strings.Repeat(`"192.0.2.1",`, maxLookupBatch+1), ",") + "]"
+	for _, body := range []string{`{"ip": "192.0.2.1"}`, tooMany} {
+		status, got := post("/v2/lookup", body)
+		if status != fiber.StatusBadRequest || !strings.Contains(string(got), `"code":"invalid_request"`) {
+			t.Errorf("got %d %s, want 400 invalid_request", status, got)
+		}
+	}
+
+	// Addresses no provider located are lookup_failed, and v1 has messages
+	located.err = errors.New("database closed")
+	_, body = post("/v2/lookup", `["192.0.2.1"]`)
+	if !strings.Contains(string(body), `"code":"lookup_failed"`) {
+		t.Errorf("failed lookup got %s, want lookup_failed", body)
+	}
+	_, body = post("/v1/lookup", `["192.0.2.1", "not an ip"]`)
+	var v1 []Response
+	if err := json.Unmarshal(body, &v1); err != nil || len(v1) != 2 ||
+		v1[0].Message != "Failed to lookup IP address" || v1[1].Message == "" {
+		t.Errorf("v1 got %s", body)
+	}
+}
+
+func TestIPLookupPath(t *testing.T) {
+	located := &stubLocator{location: &ip2location.Location{Country: "Germany", CountryCode: "DE"}}
+	app := fiber.New()
+	app.Get("/v1/lookup/:ip", useVersion(apiV1), newTestApp(t, located, located).handleIPLookup)
+
+	tests := []struct {
+		target string
+		status int
+		ip     string
+	}{
+		{"/v1/lookup/192.0.2.1", fiber.StatusOK, "192.0.2.1"},
+		// The path segment is percent-decoded
+		{"/v1/lookup/fe80::1%25eth0", fiber.StatusOK, "fe80::1"},
+		{"/v1/lookup/192.0.2.1%2F8", fiber.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		res, body := get(t, app, tt.target, "")
+		var response Response
+		if err := json.Unmarshal(body, &response); err != nil || res.StatusCode != tt.status || response.Ip != tt.ip {
+			t.Errorf("GET %s = %d %s, want %d with ip %q", tt.target, res.StatusCode, body, tt.status, tt.ip)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/goccy/go-json"
+	"github.com/imnitish-dev/ip2location/ip2location"
+)
+
+const (
+	// earthRadiusKm is the mean Earth radius used to draw accuracy circles
+	earthRadiusKm = 6371.0088
+	// circleVertices is the number of points approximating a circle
+	circleVertices = 64
+)
+
+// FeatureCollection is a GeoJSON (RFC 7946) feature collection. Message, or
+// Error on /v2, is a foreign member carrying the error of a failed lookup.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+	Message  string    `json:"message,omitempty"`
+	Error    *ErrorV2  `json:"error,omitempty"`
+}
+
+// Feature is a GeoJSON feature
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   Geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry is a GeoJSON Point or Polygon; coordinates are longitude first
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// providerLocation is one provider's result for an address
+type providerLocation struct {
+	provider string
+	location *ip2location.Location
+}
+
+// locations lists the results a response carries for its address
+func (r *Response) locations() []providerLocation {
+	var locations []providerLocation
+	if r.MaxMind != nil {
+		locations = append(locations, providerLocation{string(ip2location.MaxMindProvider), r.MaxMind})
+	}
+	if r.IP2Location != nil {
+		locations = append(locations, providerLocation{string(ip2location.IP2LocationProvider), r.IP2Location})
+	}
+	return locations
+}
+
+// encodeGeoJSON renders a Response or a []Response as a FeatureCollection
+// with a Point per provider result, and a circle when the result has an
+// accuracy radius. Host lookups give features for every resolved address.
+// The coordinates are always kept; mask selects the providers and the
+// properties.
+func encodeGeoJSON(v interface{}, mask fieldMask) ([]byte, error) {
+	collection := FeatureCollection{Type: "FeatureCollection", Features: []Feature{}}
+
+	switch v := v.(type) {
+	case Response:
+		collection.Message = v.Message
+		for _, response := range expandAddresses(v) {
+			collection.Features = append(collection.Features, responseFeatures(&response, mask)...)
+		}
+	case []Response:
+		for _, result := range v {
+			for _, response := range expandAddresses(result) {
+				collection.Features = append(collection.Features, responseFeatures(&response, mask)...)
+			}
+		}
+	case ResponseV2:
+		collection.Error = v.Error
+		for _, response := range expandAddressesV2(v) {
+			collection.Features = append(collection.Features, responseFeaturesV2(&response, mask)...)
+		}
+	case []ResponseV2:
+		for _, result := range v {
+			for _, response := range expandAddressesV2(result) {
+				collection.Features = append(collection.Features, responseFeaturesV2(&response, mask)...)
+			}
+		}
+	}
+
+	return json.Marshal(collection)
+}
+
+func responseFeatures(response *Response, mask fieldMask) []Feature {
+	var features []Feature
+	for _, result := range response.locations() {
+		loc := result.location
+		if !mask.wants(result.provider) && !mask.wants("addresses."+result.provider) {
+			continue
+		}
+		// Providers report 0,0 when they have no coordinates
+		if loc.Latitude == 0 && loc.Longitude == 0 {
+			continue
+		}
+
+		properties := make(map[string]interface{})
+		for name, value := range locationProperties(loc) {
+			path := result.provider + "." + name
+			if mask.wants(path) || mask.wants("addresses."+path) {
+				properties[name] = value
+			}
+		}
+		properties["provider"] = result.provider
+		if response.Ip != "" {
+			properties["ip"] = response.Ip
+		}
+		if response.Host != "" {
+			properties["host"] = response.Host
+		}
+
+		var radius uint16
+		if properties["accuracy_radius"] != nil {
+			radius = loc.AccuracyRadius
+		}
+		features = append(features, locationFeatures(loc.Latitude, loc.Longitude, radius, properties, map[string]interface{}{
+			"provider":        result.provider,
+			"ip":              response.Ip,
+			"accuracy_radius": loc.AccuracyRadius,
+		})...)
+	}
+	return features
+}
+
+// responseFeaturesV2 is responseFeatures for /v2, where the properties
+// keep the names of LocationV2. The merged location comes first, as
+// provider "merged".
+func responseFeaturesV2(response *ResponseV2, mask fieldMask) []Feature {
+	type result struct {
+		prefix   string
+		provider string
+		location *LocationV2
+	}
+	results := []result{{"location", "merged", response.Location}}
+	if response.Providers != nil {
+		results = append(results,
+			result{"providers." + string(ip2location.MaxMindProvider), string(ip2location.MaxMindProvider), response.Providers.MaxMind},
+			result{"providers." + string(ip2location.IP2LocationProvider), string(ip2location.IP2LocationProvider), response.Providers.IP2Location},
+		)
+	}
+
+	var features []Feature
+	for _, result := range results {
+		prefix := result.prefix
+		wants := func(name string) bool {
+			return mask.wants(prefix+"."+name) || mask.wants("addresses."+prefix+"."+name)
+		}
+		// The merged location is there whenever a provider is
+		loc := result.location
+		if loc == nil || loc.Coordinates == nil || !wants("coordinates") {
+			continue
+		}
+
+		properties := make(map[string]interface{})
+		rv := reflect.ValueOf(loc).Elem()
+		for _, field := range jsonFields(rv.Type()) {
+			fv := rv.FieldByIndex(field.index)
+			if field.name == "coordinates" || fv.IsZero() || !wants(field.name) {
+				continue
+			}
+			properties[field.name] = fv.Interface()
+		}
+
+		var radius uint16
+		if wants("coordinates.accuracy_radius_km") {
+			radius = loc.Coordinates.AccuracyRadiusKm
+		}
+		if radius > 0 {
+			properties["accuracy_radius_km"] = radius
+		}
+		properties["provider"] = result.provider
+		if response.IP != "" {
+			properties["ip"] = response.IP
+		}
+		if response.Host != "" {
+			properties["host"] = response.Host
+		}
+
+		features = append(features, locationFeatures(loc.Coordinates.Latitude, loc.Coordinates.Longitude, radius, properties, map[string]interface{}{
+			"provider":           result.provider,
+			"ip":                 response.IP,
+			"accuracy_radius_km": radius,
+		})...)
+	}
+	return features
+}
+
+// locationFeatures returns a Point feature, and a Polygon approximating the
+// accuracy circle when radiusKm is set
+func locationFeatures(lat, lon float64, radiusKm uint16, properties, circleProperties map[string]interface{}) []Feature {
+	features := []Feature{{
+		Type: "Feature",
+		Geometry: Geometry{
+			Type:        "Point",
+			Coordinates: []float64{lon, lat},
+		},
+		Properties: properties,
+	}}
+
+	if radiusKm > 0 {
+		features = append(features, Feature{
+			Type: "Feature",
+			Geometry: Geometry{
+				Type:        "Polygon",
+				Coordinates: [][][]float64{circle(lat, lon, float64(radiusKm))},
+			},
+			Properties: circleProperties,
+		})
+	}
+	return features
+}
+
+// locationProperties returns every Location field except the coordinates,
+// under its JSON name
+func locationProperties(loc *ip2location.Location) map[string]interface{} {
+	properties := make(map[string]interface{})
+	rv := reflect.ValueOf(loc).Elem()
+	for _, field := range jsonFields(rv.Type()) {
+		if field.name == "latitude" || field.name == "longitude" {
+			continue
+		}
+		fv := rv.FieldByIndex(field.index)
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+		properties[field.name] = fv.Interface()
+	}
+	return properties
+}
+
+// circle approximates a circle of radiusKm around a point as a closed,
+// counterclockwise linear ring of [longitude, latitude] positions
+func circle(lat, lon, radiusKm float64) [][]float64 {
+	lat1 := lat * math.Pi / 180
+	lon1 := lon * math.Pi / 180
+	dist := radiusKm / earthRadiusKm
+
+	ring := make([][]float64, 0, circleVertices+1)
+	for i := 0; i < circleVertices; i++ {
+		// Decreasing bearings wind the ring counterclockwise
+		bearing := -2 * math.Pi * float64(i) / circleVertices
+
+		lat2 := math.Asin(math.Sin(lat1)*math.Cos(dist) + math.Cos(lat1)*math.Sin(dist)*math.Cos(bearing))
+		lon2 := lon1 + math.Atan2(math.Sin(bearing)*math.Sin(dist)*math.Cos(lat1), math.Cos(dist)-math.Sin(lat1)*math.Sin(lat2))
+
+		ring = append(ring, []float64{
+			round(math.Mod(lon2*180/math.Pi+540, 360) - 180),
+			round(lat2 * 180 / math.Pi),
+		})
+	}
+	return append(ring, ring[0])
+}
+
+// round trims positions to six decimals, about 10 cm
+func round(x float64) float64 {
+	return math.Round(x*1e6) / 1e6
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/imnitish-dev/ip2location/ip2location"
+)
+
+// signedArea is the shoelace area of a ring, positive when it winds
+// counterclockwise
+func signedArea(ring [][]float64) float64 {
+	var area float64
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+func TestCircle(t *testing.T) {
+	ring := circle(37.4, -122.1, 50)
+	if len(ring) != circleVertices+1 {
+		t.Fatalf("ring has %d positions, want %d", len(ring), circleVertices+1)
+	}
+	first, last := ring[0], ring[len(ring)-1]
+	if first[0] != last[0] || first[1] != last[1] {
+		t.Errorf("ring is not closed: %v != %v", first, last)
+	}
+	if area := signedArea(ring); area <= 0 {
+		t.Errorf("ring winds clockwise (area %f)", area)
+	}
+
+	// Every vertex is about 50 km from the centre
+	for _, p := range ring {
+		dLat := (p[1] - 37.4) * math.Pi / 180
+		dLon := (p[0] + 122.1) * math.Pi / 180
+		a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+			math.Cos(37.4*math.Pi/180)*math.Cos(p[1]*math.Pi/180)*math.Sin(dLon/2)*math.Sin(dLon/2)
+		if d := 2 * earthRadiusKm * math.Asin(math.Sqrt(a)); math.Abs(d-50) > 0.01 {
+			t.Errorf("vertex %v is %f km away", p, d)
+		}
+	}
+}
+
+func TestCircleAntimeridian(t *testing.T) {
+	for _, lon := range []float64{179.9, -179.9, 180, -180} {
+		ring := circle(-16.5, lon, 100)
+		var east, west bool
+		for _, p := range ring {
+			if p[0] < -180 || p[0] > 180 {
+				t.Errorf("circle(%v) has longitude %v", lon, p[0])
+			}
+			if p[1] < -90 || p[1] > 90 {
+				t.Errorf("circle(%v) has latitude %v", lon, p[1])
+			}
+			east = east || p[0] > 0
+			west = west || p[0] < 0
+		}
+		// The circle crosses the antimeridian, so it wraps around
+		if !east || !west {
+			t.Errorf("circle(%v) does not wrap: %v", lon, ring)
+		}
+	}
+}
+
+func TestEncodeGeoJSON(t *testing.T) {
+	response := Response{
+		Ip: "192.0.2.1",
+		MaxMind: &ip2location.Location{
+			Country: "United States", CountryCode: "US", City: "Mountain View",
+			Latitude: 37.4, Longitude: -122.1, AccuracyRadius: 20,
+		},
+		// No coordinates
+		IP2Location: &ip2location.Location{Country: "United States of America", CountryCode: "US"},
+	}
+
+	decode := func(mask fieldMask) FeatureCollection {
+		t.Helper()
+		data, err := encodeGeoJSON(response, mask)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var collection FeatureCollection
+		if err := json.Unmarshal(data, &collection); err != nil {
+			t.Fatal(err)
+		}
+		return collection
+	}
+
+	collection := decode(nil)
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 2 {
+		t.Fatalf("got %+v, want a point and a circle for MaxMind only", collection)
+	}
+	point, polygon := collection.Features[0], collection.Features[1]
+	if point.Geometry.Type != "Point" || polygon.Geometry.Type != "Polygon" {
+		t.Errorf("geometries are %s and %s", point.Geometry.Type, polygon.Geometry.Type)
+	}
+	if coordinates, _ := point.Geometry.Coordinates.([]interface{}); len(coordinates) != 2 || coordinates[0] != -122.1 || coordinates[1] != 37.4 {
+		t.Errorf("point is at %v, want longitude first", point.Geometry.Coordinates)
+	}
+	if point.Properties["provider"] != "maxmind" || point.Properties["city"] != "Mountain View" || point.Properties["ip"] != "192.0.2.1" {
+		t.Errorf("point properties are %v", point.Properties)
+	}
+
+	// The mask selects properties but the coordinates are always kept
+	collection = decode(fieldMask{"maxmind.city", "message"})
+	if len(collection.Features) != 1 {
+		t.Fatalf("got %d features, want the point without a circle", len(collection.Features))
+	}
+	properties := collection.Features[0].Properties
+	if properties["city"] != "Mountain View" || properties["country_code"] != nil || properties["accuracy_radius"] != nil {
+		t.Errorf("masked properties are %v", properties)
+	}
+
+	// Unselected providers give no features
+	if collection := decode(fieldMask{"ip2location", "message"}); len(collection.Features) != 0 {
+		t.Errorf("got %+v, want no features", collection.Features)
+	}
+
+	// Errors are kept as a foreign member and the features are an empty list
+	data, err := encodeGeoJSON(Response{Message: "invalid IP address"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"FeatureCollection","features":[],"message":"invalid IP address"}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestEncodeGeoJSONV2(t *testing.T) {
+	location := &LocationV2{
+		City:        "Mountain View",
+		Country:     &CountryV2{Code: "US"},
+		Coordinates: &CoordinatesV2{Latitude: 37.4, Longitude: -122.1, AccuracyRadiusKm: 20},
+	}
+	response := ResponseV2{
+		IP:        "192.0.2.1",
+		Location:  location,
+		Providers: &ProvidersV2{MaxMind: location, IP2Location: &LocationV2{City: "Mountain View"}},
+	}
+
+	data, err := encodeGeoJSON(response, fieldMask{"location.city", "location.coordinates", "error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var collection FeatureCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		t.Fatal(err)
+	}
+	if len(collection.Features) != 2 {
+		t.Fatalf("got %s, want the merged point and circle", data)
+	}
+	properties := collection.Features[0].Properties
+	if properties["provider"] != "merged" || properties["city"] != "Mountain View" || properties["country"] != nil ||
+		properties["accuracy_radius_km"] != 20.0 {
+		t.Errorf("merged properties are %v", properties)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/ip2location"
+	"github.com/imnitish-dev/ip2location/resolver"
+)
+
+// maxHostAddresses caps how many resolved addresses are geolocated per host
+const maxHostAddresses = 16
+
+// ResolvedAddress holds the geolocation of one address a host resolved to
+type ResolvedAddress struct {
+	IP          string                `json:"ip"`
+	Type        string                `json:"type"`
+	TTL         uint32                `json:"ttl"`
+	MaxMind     *ip2location.Location `json:"maxmind,omitempty"`
+	IP2Location *ip2location.Location `json:"ip2location,omitempty"`
+
+	// Result is the lookup behind MaxMind and IP2Location
+	Result *ip2location.Result `json:"-"`
+}
+
+// lookupHost resolves host and geolocates every returned address with the
+// providers work asks for
+func (a *App) lookupHost(ctx context.Context, host string, work lookupWork) ([]ResolvedAddress, error) {
+	records, err := a.resolver.Lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > maxHostAddresses {
+		records = records[:maxHostAddresses]
+	}
+
+	addresses := make([]ResolvedAddress, len(records))
+	var wg sync.WaitGroup
+	for i, record := range records {
+		addresses[i] = ResolvedAddress{
+			IP:   ip2location.NormalizeAddr(record.IP).String(),
+			Type: record.Type,
+			TTL:  record.TTL,
+		}
+
+		wg.Add(1)
+		go func(address *ResolvedAddress) {
+			defer wg.Done()
+			address.Result = a.lookup(ctx, address.IP, work)
+			address.MaxMind = address.Result.Locations[string(ip2location.MaxMindProvider)]
+			address.IP2Location = address.Result.Locations[string(ip2location.IP2LocationProvider)]
+		}(&addresses[i])
+	}
+	wg.Wait()
+
+	return addresses, nil
+}
+
+func (a *App) handleHostLookup(c *fiber.Ctx, host string, work lookupWork) error {
+	addresses, err := a.lookupHost(c.UserContext(), host, work)
+	switch {
+	case errors.Is(err, resolver.ErrNotFound):
+		return render(c.Status(fiber.StatusNotFound), Response{
+			Message: err.Error(),
+		})
+	case err != nil:
+		return render(c.Status(fiber.StatusBadGateway), Response{
+			Message: "DNS resolution failed: " + err.Error(),
+		})
+	}
+
+	var deviceBrowser DeviceInfo
+	if work.device {
+		deviceBrowser = a.getDeviceInfo(c)
+	}
+
+	return render(c, Response{
+		Host:          host,
+		Addresses:     addresses,
+		DeviceBrowser: deviceBrowser,
+	})
+}
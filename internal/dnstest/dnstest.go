@@ -0,0 +1,187 @@
+// Package dnstest runs a DNS server on 127.0.0.1 for tests, answering over
+// UDP and TCP from a fixed zone.
+package dnstest
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Server answers queries from Zone, keyed by fully qualified name. Names
+// missing from the zone are answered with NXDOMAIN and names without
+// records of the queried type with an empty answer.
+type Server struct {
+	// Addr is the UDP and TCP address the server listens on
+	Addr string
+	Zone map[string][]dnsmessage.Resource
+	// Truncate names are answered with TC set over UDP, forcing a retry
+	// over TCP; Silent names are never answered
+	Truncate map[string]bool
+	Silent   map[string]bool
+	// Failing answers everything with SERVFAIL while set
+	Failing atomic.Bool
+
+	Queries    atomic.Int32
+	TCPQueries atomic.Int32
+}
+
+// Start starts s on a port free for both UDP and TCP. It is stopped when
+// the test ends.
+func Start(t testing.TB, s *Server) *Server {
+	t.Helper()
+	for attempt := 0; ; attempt++ {
+		udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		tcp, err := net.Listen("tcp", udp.LocalAddr().String())
+		if err != nil {
+			udp.Close()
+			if attempt < 10 {
+				continue
+			}
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			udp.Close()
+			tcp.Close()
+		})
+		s.Addr = udp.LocalAddr().String()
+		go s.serveUDP(udp)
+		go s.serveTCP(tcp)
+		return s
+	}
+}
+
+// Resolver returns a resolver that only talks to s
+func (s *Server) Resolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, s.Addr)
+		},
+	}
+}
+
+func (s *Server) serveUDP(conn net.PacketConn) {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if resp := s.answer(buf[:n], false); resp != nil {
+			conn.WriteTo(resp, addr)
+		}
+	}
+}
+
+func (s *Server) serveTCP(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			s.TCPQueries.Add(1)
+
+			var length [2]byte
+			if _, err := io.ReadFull(conn, length[:]); err != nil {
+				return
+			}
+			req := make([]byte, binary.BigEndian.Uint16(length[:]))
+			if _, err := io.ReadFull(conn, req); err != nil {
+				return
+			}
+			resp := s.answer(req, true)
+			if resp == nil {
+				return
+			}
+			framed := binary.BigEndian.AppendUint16(nil, uint16(len(resp)))
+			conn.Write(append(framed, resp...))
+		}()
+	}
+}
+
+func (s *Server) answer(req []byte, overTCP bool) []byte {
+	s.Queries.Add(1)
+
+	var p dnsmessage.Parser
+	header, err := p.Start(req)
+	if err != nil {
+		return nil
+	}
+	question, err := p.Question()
+	if err != nil {
+		return nil
+	}
+	name := question.Name.String()
+	if s.Silent[name] {
+		return nil
+	}
+
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID: header.ID, Response: true, RecursionDesired: header.RecursionDesired, RecursionAvailable: true,
+		},
+		Questions: []dnsmessage.Question{question},
+	}
+	records, ok := s.Zone[name]
+	switch {
+	case s.Failing.Load():
+		msg.RCode = dnsmessage.RCodeServerFailure
+	case !ok:
+		msg.RCode = dnsmessage.RCodeNameError
+	case s.Truncate[name] && !overTCP:
+		msg.Truncated = true
+	default:
+		for _, record := range records {
+			if record.Header.Type == question.Type {
+				msg.Answers = append(msg.Answers, record)
+			}
+		}
+	}
+
+	resp, err := msg.Pack()
+	if err != nil {
+		return nil
+	}
+	return resp
+}
+
+// A returns an A record
+func A(name, ip string, ttl uint32) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: header(name, dnsmessage.TypeA, ttl),
+		Body:   &dnsmessage.AResource{A: netip.MustParseAddr(ip).As4()},
+	}
+}
+
+// AAAA returns an AAAA record
+func AAAA(name, ip string, ttl uint32) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: header(name, dnsmessage.TypeAAAA, ttl),
+		Body:   &dnsmessage.AAAAResource{AAAA: netip.MustParseAddr(ip).As16()},
+	}
+}
+
+// PTR returns a PTR record pointing name at target
+func PTR(name, target string, ttl uint32) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: header(name, dnsmessage.TypePTR, ttl),
+		Body:   &dnsmessage.PTRResource{PTR: dnsmessage.MustNewName(target)},
+	}
+}
+
+func header(name string, typ dnsmessage.Type, ttl uint32) dnsmessage.ResourceHeader {
+	return dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Type: typ, Class: dnsmessage.ClassINET, TTL: ttl}
+}
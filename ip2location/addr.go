@@ -0,0 +1,182 @@
+package ip2location
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+var (
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+	teredoPrefix    = netip.MustParsePrefix("2001::/32")
+	nat64Prefix     = netip.MustParsePrefix("64:ff9b::/96")
+)
+
+// ParseAddr parses an IP address and returns the normalized address that
+// should be geolocated. Besides the standard IPv4 and IPv6 forms (optionally
+// bracketed and/or carrying a zone ID) it accepts:
+//
+//	16909060              decimal 32-bit integer
+//	0x01020304            hex 32-bit integer
+//	1-2-3-4               dashed quad
+//	4.3.2.1.in-addr.arpa  IPv4 reverse-DNS name
+//	...ip6.arpa           IPv6 reverse-DNS name (all 32 nibbles)
+//
+// Forms that different parsers read differently, such as octal-looking
+// leading zeros or shortened quads like "10.1", fail with ErrAmbiguousIP.
+func ParseAddr(s string) (netip.Addr, error) {
+	addr, err := parseNotation(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return NormalizeAddr(addr), nil
+}
+
+func parseNotation(s string) (netip.Addr, error) {
+	name := strings.ToLower(strings.TrimSuffix(s, "."))
+
+	switch {
+	case s == "":
+		return netip.Addr{}, ErrInvalidIP
+	case strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]"):
+		return parseIPv6(s[1 : len(s)-1])
+	case strings.HasSuffix(name, ".in-addr.arpa"):
+		return parseInAddrArpa(strings.TrimSuffix(name, ".in-addr.arpa"))
+	case strings.HasSuffix(name, ".ip6.arpa"):
+		return parseIP6Arpa(strings.TrimSuffix(name, ".ip6.arpa"))
+	case strings.Contains(s, ":"):
+		return parseIPv6(s)
+	case strings.HasPrefix(name, "0x"):
+		return parseUint32(name[2:], 16)
+	case isDigits(s):
+		if len(s) > 1 && s[0] == '0' {
+			return netip.Addr{}, ErrAmbiguousIP
+		}
+		return parseUint32(s, 10)
+	case strings.Count(s, "-") == 3 && !strings.Contains(s, "."):
+		return parseQuad(strings.Split(s, "-"))
+	default:
+		return parseQuad(strings.Split(s, "."))
+	}
+}
+
+func parseIPv6(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil || !addr.Is6() {
+		return netip.Addr{}, ErrInvalidIP
+	}
+	return addr, nil
+}
+
+func parseUint32(s string, base int) (netip.Addr, error) {
+	if s == "" {
+		return netip.Addr{}, ErrInvalidIP
+	}
+	n, err := strconv.ParseUint(s, base, 32)
+	if err != nil {
+		return netip.Addr{}, ErrInvalidIP
+	}
+	return netip.AddrFrom4([4]byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}), nil
+}
+
+// parseQuad parses the four decimal octets of an IPv4 address
+func parseQuad(parts []string) (netip.Addr, error) {
+	for _, part := range parts {
+		if !isDigits(part) {
+			return netip.Addr{}, ErrInvalidIP
+		}
+	}
+	if len(parts) < 4 {
+		// inet_aton would read "10.1" as 10.0.0.1
+		return netip.Addr{}, ErrAmbiguousIP
+	}
+	if len(parts) > 4 {
+		return netip.Addr{}, ErrInvalidIP
+	}
+
+	var b [4]byte
+	for i, part := range parts {
+		if len(part) > 1 && part[0] == '0' {
+			// Leading zeros are octal to some parsers and decimal to others
+			return netip.Addr{}, ErrAmbiguousIP
+		}
+		n, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return netip.Addr{}, ErrInvalidIP
+		}
+		b[i] = byte(n)
+	}
+	return netip.AddrFrom4(b), nil
+}
+
+func parseInAddrArpa(name string) (netip.Addr, error) {
+	labels := strings.Split(name, ".")
+	if len(labels) != 4 {
+		// Fewer labels name a network, not an address
+		return netip.Addr{}, ErrInvalidIP
+	}
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return parseQuad(labels)
+}
+
+func parseIP6Arpa(name string) (netip.Addr, error) {
+	labels := strings.Split(name, ".")
+	if len(labels) != 32 {
+		return netip.Addr{}, ErrInvalidIP
+	}
+
+	var b [16]byte
+	for i, label := range labels {
+		if len(label) != 1 {
+			return netip.Addr{}, ErrInvalidIP
+		}
+		n, err := strconv.ParseUint(label, 16, 4)
+		if err != nil {
+			return netip.Addr{}, ErrInvalidIP
+		}
+		// Nibbles are listed least significant first
+		pos := 31 - i
+		if pos%2 == 0 {
+			b[pos/2] |= byte(n) << 4
+		} else {
+			b[pos/2] |= byte(n)
+		}
+	}
+	return netip.AddrFrom16(b), nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeAddr strips the zone, unmaps IPv4-mapped IPv6 addresses and
+// extracts the IPv4 address embedded in 6to4, Teredo and NAT64 addresses,
+// since that is the host whose location is meaningful.
+func NormalizeAddr(addr netip.Addr) netip.Addr {
+	addr = addr.WithZone("").Unmap()
+	if !addr.Is6() {
+		return addr
+	}
+
+	b := addr.As16()
+	switch {
+	case nat64Prefix.Contains(addr):
+		return netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]})
+	case sixToFourPrefix.Contains(addr):
+		return netip.AddrFrom4([4]byte{b[2], b[3], b[4], b[5]})
+	case teredoPrefix.Contains(addr):
+		// The Teredo client address is stored inverted in the last 32 bits
+		return netip.AddrFrom4([4]byte{^b[12], ^b[13], ^b[14], ^b[15]})
+	}
+	return addr
+}
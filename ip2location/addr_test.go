@@ -0,0 +1,127 @@
+package ip2location
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestParseAddr(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  error
+	}{
+		// Standard forms
+		{in: "192.0.2.1", want: "192.0.2.1"},
+		{in: " 192.0.2.1\n", want: "192.0.2.1"},
+		{in: "0.0.0.0", want: "0.0.0.0"},
+		{in: "2001:db8::1", want: "2001:db8::1"},
+		{in: "2001:DB8:0:0:0:0:0:1", want: "2001:db8::1"},
+		{in: "[2001:db8::1]", want: "2001:db8::1"},
+		{in: "fe80::1%eth0", want: "fe80::1"},
+		{in: "[fe80::1%25eth0]", want: "fe80::1"},
+		{in: "::ffff:192.0.2.1", want: "192.0.2.1"},
+		{in: "[::ffff:192.0.2.1]", want: "192.0.2.1"},
+
+		// Integers
+		{in: "3221225985", want: "192.0.2.1"},
+		{in: "0", want: "0.0.0.0"},
+		{in: "4294967295", want: "255.255.255.255"},
+		{in: "0xC0000201", want: "192.0.2.1"},
+		{in: "0Xc0000201", want: "192.0.2.1"},
+		{in: "0x1", want: "0.0.0.1"},
+
+		// Dashed quads
+		{in: "192-0-2-1", want: "192.0.2.1"},
+
+		// Reverse DNS
+		{in: "1.2.0.192.in-addr.arpa", want: "192.0.2.1"},
+		{in: "1.2.0.192.IN-ADDR.ARPA.", want: "192.0.2.1"},
+		{in: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", want: "2001:db8::1"},
+		{in: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.B.D.0.1.0.0.2.IP6.ARPA.", want: "2001:db8::1"},
+
+		// Read differently by different parsers
+		{in: "010.0.0.1", err: ErrAmbiguousIP},
+		{in: "192.168.1.01", err: ErrAmbiguousIP},
+		{in: "192-0-2-01", err: ErrAmbiguousIP},
+		{in: "1.2.0.010.in-addr.arpa", err: ErrAmbiguousIP},
+		{in: "03000001001", err: ErrAmbiguousIP},
+		{in: "00", err: ErrAmbiguousIP},
+		{in: "10.1", err: ErrAmbiguousIP},
+		{in: "10.1.1", err: ErrAmbiguousIP},
+		{in: "127.1", err: ErrAmbiguousIP},
+
+		// Not addresses
+		{in: "", err: ErrInvalidIP},
+		{in: "   ", err: ErrInvalidIP},
+		{in: "256.0.0.1", err: ErrInvalidIP},
+		{in: "1.2.3.4.5", err: ErrInvalidIP},
+		{in: "1.2.3.", err: ErrInvalidIP},
+		{in: "1..2.3", err: ErrInvalidIP},
+		{in: "4294967296", err: ErrInvalidIP},
+		{in: "0x", err: ErrInvalidIP},
+		{in: "0x100000000", err: ErrInvalidIP},
+		{in: "0xc00002g1", err: ErrInvalidIP},
+		{in: "-1", err: ErrInvalidIP},
+		{in: "1-2-3", err: ErrInvalidIP},
+		{in: "1-2-3-4-5", err: ErrInvalidIP},
+		{in: "1-2-3-256", err: ErrInvalidIP},
+		{in: "[192.0.2.1]", err: ErrInvalidIP},
+		{in: "[2001:db8::1", err: ErrInvalidIP},
+		{in: "2001:db8::g", err: ErrInvalidIP},
+		{in: "192.0.2.1:80", err: ErrInvalidIP},
+		{in: "192.0.2.0/24", err: ErrInvalidIP},
+		{in: "example.com", err: ErrInvalidIP},
+		{in: "2.0.192.in-addr.arpa", err: ErrInvalidIP},
+		{in: "5.1.2.0.192.in-addr.arpa", err: ErrInvalidIP},
+		{in: "x.2.0.192.in-addr.arpa", err: ErrInvalidIP},
+		{in: "0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", err: ErrInvalidIP},
+		{in: "g.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", err: ErrInvalidIP},
+		{in: "10.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", err: ErrInvalidIP},
+	}
+	for _, tt := range tests {
+		got, err := ParseAddr(tt.in)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("ParseAddr(%q) = %v, %v; want %v", tt.in, got, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got.String() != tt.want {
+			t.Errorf("ParseAddr(%q) = %v, %v; want %s", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizeAddr(t *testing.T) {
+	tests := map[string]string{
+		// Embedded IPv4 addresses
+		"::ffff:192.0.2.1":                     "192.0.2.1",
+		"::ffff:c000:201":                      "192.0.2.1",
+		"2002:c000:204::1":                     "192.0.2.4",
+		"2002:cb00:710a:1::abcd":               "203.0.113.10",
+		"2001:0:4136:e378:8000:63bf:3fff:fdd2": "192.0.2.45",
+		"2001::ffff:ffff":                      "0.0.0.0",
+		"64:ff9b::c000:221":                    "192.0.2.33",
+		"64:ff9b::192.0.2.33":                  "192.0.2.33",
+		"fe80::1%eth0":                         "fe80::1",
+		"::ffff:192.0.2.1%eth0":                "192.0.2.1",
+
+		// Left alone
+		"192.0.2.1":            "192.0.2.1",
+		"2001:db8::1":          "2001:db8::1",
+		"2001:4860:4860::8888": "2001:4860:4860::8888",
+		"2001:1::1":            "2001:1::1",
+		"2003::1":              "2003::1",
+		"64:ff9b:1::c000:221":  "64:ff9b:1::c000:221",
+		"::192.0.2.1":          "::c000:201",
+		"::1":                  "::1",
+		"::":                   "::",
+	}
+	for in, want := range tests {
+		if got := NormalizeAddr(netip.MustParseAddr(in)); got.String() != want {
+			t.Errorf("NormalizeAddr(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package ip2location
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/imnitish-dev/ip2location/countries"
+	"github.com/imnitish-dev/ip2location/timezone"
+)
+
+// DefaultTimeout bounds an Aggregator lookup when no timeout is configured
+const DefaultTimeout = 2 * time.Second
+
+// Policy decides which sources an Aggregator asks and how long it waits
+type Policy int
+
+const (
+	// All asks every source at once and waits for all of them
+	All Policy = iota
+	// FirstSuccess asks the sources one at a time in priority order and
+	// stops at the first that locates the address
+	FirstSuccess
+	// Quorum asks every source at once and returns as soon as Quorum of
+	// them locate the address
+	Quorum
+)
+
+// ParsePolicy parses all, first_success or quorum
+func ParsePolicy(s string) (Policy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "all":
+		return All, nil
+	case "first_success":
+		return FirstSuccess, nil
+	case "quorum":
+		return Quorum, nil
+	}
+	return All, fmt.Errorf("unknown lookup policy %q", s)
+}
+
+// Locator is what an Aggregator looks addresses up in; *Service is one.
+// A Locator with a Provider method names its source after the provider,
+// and one with a Close method is closed by Aggregator.Close.
+type Locator interface {
+	Lookup(ip string) (*Location, error)
+}
+
+// Source is a Locator taking part in an Aggregator
+type Source struct {
+	Service Locator
+	// Name identifies the source in results; the service's provider when
+	// empty. Names must be unique.
+	Name string
+	// Priority orders the sources: higher ones are asked first by
+	// FirstSuccess and win when results are merged
+	Priority int
+	// Timeout bounds the lookups of this source; the aggregator's when
+	// zero
+	Timeout time.Duration
+}
+
+// AggregatorConfig configures an Aggregator
+type AggregatorConfig struct {
+	// Timeout bounds a whole lookup; DefaultTimeout when zero
+	Timeout time.Duration
+	Policy  Policy
+	// Quorum is the number of sources that must locate an address under
+	// the Quorum policy; a majority when zero
+	Quorum int
+	// TimeZones replaces missing or non-IANA time zones with the zone at a
+	// location's coordinates when LookupOptions.TimeZones is set
+	TimeZones *timezone.Finder
+}
+
+// LookupOptions selects what an Aggregator lookup does
+type LookupOptions struct {
+	// Sources limits the lookup to the named sources; nil asks them all
+	Sources []string
+	// TimeZones fills in time zones the sources do not report
+	TimeZones bool
+}
+
+// Result holds the outcome of each source and their merged location
+type Result struct {
+	// Locations are the results of the sources that answered, by name.
+	// A source that does not know an address returns an empty location.
+	Locations map[string]*Location
+	// Errors are the failures of the sources that did not answer, which
+	// is ErrTimeout when they ran out of time
+	Errors map[string]error
+	// Merged combines the locations field by field, preferring sources of
+	// higher priority; it is nil when no source answered
+	Merged *Location
+}
+
+// Aggregator looks addresses up in several Services concurrently and
+// merges their results. It is safe for concurrent use.
+type Aggregator struct {
+	sources []Source // by priority, highest first
+	config  AggregatorConfig
+}
+
+// NewAggregator returns an Aggregator over sources
+func NewAggregator(config AggregatorConfig, sources ...Source) (*Aggregator, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	a := &Aggregator{config: config}
+	names := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		if source.Service == nil {
+			return nil, fmt.Errorf("source %q has no service", source.Name)
+		}
+		if source.Name == "" {
+			if provider, ok := source.Service.(interface{ Provider() Provider }); ok {
+				source.Name = string(provider.Provider())
+			}
+		}
+		if source.Name == "" {
+			return nil, fmt.Errorf("a source has no name")
+		}
+		if names[source.Name] {
+			return nil, fmt.Errorf("duplicate source %q", source.Name)
+		}
+		names[source.Name] = true
+		a.sources = append(a.sources, source)
+	}
+	sort.SliceStable(a.sources, func(i, j int) bool {
+		return a.sources[i].Priority > a.sources[j].Priority
+	})
+
+	if config.Policy == Quorum && config.Quorum > len(a.sources) {
+		return nil, fmt.Errorf("quorum of %d exceeds the %d sources", config.Quorum, len(a.sources))
+	}
+	return a, nil
+}
+
+// Sources returns the names of the sources, by priority
+func (a *Aggregator) Sources() []string {
+	names := make([]string, len(a.sources))
+	for i, source := range a.sources {
+		names[i] = source.Name
+	}
+	return names
+}
+
+// Close closes the services of every source that can be closed
+func (a *Aggregator) Close() {
+	for _, source := range a.sources {
+		if closer, ok := source.Service.(interface{ Close() }); ok {
+			closer.Close()
+		}
+	}
+}
+
+// answer is the outcome of one source
+type answer struct {
+	name     string
+	location *Location
+	err      error
+}
+
+// Lookup geolocates ip with the sources options selects, following the
+// policy. Sources that do not answer within their timeout, or before the
+// lookup ends, are reported with ErrTimeout; those still running when a
+// quorum is reached are left out of the result. Results are reconciled, so
+// that sources without GeoNames IDs get those of sources that agree with
+// them, before they are merged.
+func (a *Aggregator) Lookup(ctx context.Context, ip string, options LookupOptions) *Result {
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+
+	sources := a.selected(options.Sources)
+	result := &Result{
+		Locations: make(map[string]*Location, len(sources)),
+		Errors:    make(map[string]error),
+	}
+
+	record := func(ans answer) {
+		if ans.err != nil {
+			result.Errors[ans.name] = ans.err
+			return
+		}
+		if options.TimeZones {
+			a.fillTimeZone(ans.location)
+		}
+		result.Locations[ans.name] = ans.location
+	}
+
+	switch a.config.Policy {
+	case FirstSuccess:
+		for _, source := range sources {
+			answers := make(chan answer, 1)
+			a.ask(ctx, source, ip, answers)
+			ans := <-answers
+			record(ans)
+			if ans.err == nil && located(ans.location) {
+				break
+			}
+		}
+
+	default:
+		quorum := len(sources)
+		if a.config.Policy == Quorum {
+			quorum = a.quorum(len(sources))
+		}
+
+		answers := make(chan answer, len(sources))
+		for _, source := range sources {
+			a.ask(ctx, source, ip, answers)
+		}
+		for pending, successes := len(sources), 0; pending > 0 && successes < quorum; pending-- {
+			ans := <-answers
+			record(ans)
+			if ans.err == nil && (a.config.Policy == All || located(ans.location)) {
+				successes++
+			}
+		}
+	}
+
+	a.reconcile(result, sources)
+	result.Merged = merge(result, sources)
+	return result
+}
+
+// selected returns the sources named, or all of them
+func (a *Aggregator) selected(names []string) []Source {
+	if names == nil {
+		return a.sources
+	}
+	var sources []Source
+	for _, source := range a.sources {
+		for _, name := range names {
+			if source.Name == name {
+				sources = append(sources, source)
+				break
+			}
+		}
+	}
+	return sources
+}
+
+func (a *Aggregator) quorum(sources int) int {
+	quorum := a.config.Quorum
+	if quorum <= 0 {
+		quorum = sources/2 + 1
+	}
+	if quorum > sources {
+		quorum = sources
+	}
+	return quorum
+}
+
+// ask looks ip up in source in the background and sends the answer to
+// answers, or ErrTimeout once the source's time is up; a lookup still
+// running then finishes unobserved. answers must have room for the answer.
+func (a *Aggregator) ask(ctx context.Context, source Source, ip string, answers chan<- answer) {
+	done := make(chan answer, 1)
+	go func() {
+		location, err := source.Service.Lookup(ip)
+		done <- answer{name: source.Name, location: location, err: err}
+	}()
+
+	go func() {
+		if source.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, source.Timeout)
+			defer cancel()
+		}
+		select {
+		case ans := <-done:
+			answers <- ans
+		case <-ctx.Done():
+			answers <- answer{name: source.Name, err: ErrTimeout}
+		}
+	}()
+}
+
+// fillTimeZone replaces a missing or non-IANA time zone with the zone at
+// the location's coordinates
+func (a *Aggregator) fillTimeZone(loc *Location) {
+	if timezone.Valid(loc.TimeZone) {
+		return
+	}
+	loc.TimeZone = ""
+	if a.config.TimeZones != nil && (loc.Latitude != 0 || loc.Longitude != 0) {
+		loc.TimeZone = a.config.TimeZones.Lookup(loc.Latitude, loc.Longitude)
+	}
+}
+
+// reconcile gives sources without GeoNames IDs those of the sources that
+// record them, in priority order
+func (a *Aggregator) reconcile(result *Result, sources []Source) {
+	for _, reference := range sources {
+		ref := result.Locations[reference.Name]
+		if ref == nil || ref.CountryGeoNameID == 0 {
+			continue
+		}
+		for _, source := range sources {
+			other := result.Locations[source.Name]
+			if other != nil && other != ref && other.CountryGeoNameID == 0 {
+				Reconcile(ref, other)
+			}
+		}
+	}
+}
+
+// located reports whether a source found the country of an address
+func located(loc *Location) bool {
+	return loc != nil && known(loc.CountryCode)
+}
+
+// known reports whether a field holds a value; IP2Location reports "-"
+// for unknown ones
+func known(s string) bool {
+	return s != "" && s != "-"
+}
+
+// merge combines the locations, taking each group of related fields from
+// the highest-priority source that has them. Regions, cities, coordinates
+// and time zones only come from sources that agree on the country.
+func merge(result *Result, sources []Source) *Location {
+	var locations []*Location
+	for _, source := range sources {
+		if loc := result.Locations[source.Name]; loc != nil {
+			locations = append(locations, loc)
+		}
+	}
+	if len(locations) == 0 {
+		return nil
+	}
+
+	merged := &Location{}
+	for _, loc := range locations {
+		if located(loc) {
+			merged.Country, merged.CountryCode, merged.CountryGeoNameID = loc.Country, loc.CountryCode, loc.CountryGeoNameID
+			break
+		}
+	}
+
+	for _, loc := range locations {
+		if merged.ASN == nil {
+			merged.ASN = loc.ASN
+		}
+		if merged.Anonymizer == nil {
+			merged.Anonymizer = loc.Anonymizer
+		}
+		if merged.CountryCode != "" && !strings.EqualFold(loc.CountryCode, merged.CountryCode) {
+			continue
+		}
+		if merged.Region == "" && merged.RegionCode == "" && (known(loc.Region) || loc.RegionCode != "") {
+			merged.Region, merged.RegionCode, merged.RegionGeoNameID = loc.Region, loc.RegionCode, loc.RegionGeoNameID
+		}
+		if merged.City == "" && known(loc.City) {
+			merged.City, merged.CityGeoNameID = loc.City, loc.CityGeoNameID
+		}
+		if merged.Latitude == 0 && merged.Longitude == 0 && (loc.Latitude != 0 || loc.Longitude != 0) {
+			merged.Latitude, merged.Longitude, merged.AccuracyRadius = loc.Latitude, loc.Longitude, loc.AccuracyRadius
+		}
+		if merged.TimeZone == "" && timezone.Valid(loc.TimeZone) {
+			merged.TimeZone = loc.TimeZone
+		}
+	}
+
+	// The region keeps the source's name; the ISO 3166-2 dataset only
+	// names it when the source gave a code alone
+	if !known(merged.Region) {
+		if subdivision, ok := countries.LookupSubdivision(merged.RegionCode); ok {
+			merged.Region = subdivision.Name
+		}
+	}
+	return merged
+}
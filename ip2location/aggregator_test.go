@@ -0,0 +1,425 @@
+package ip2location
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stub is a Locator answering every address with a copy of location, or
+// with err, after delay
+type stub struct {
+	location *Location
+	err      error
+	delay    time.Duration
+	calls    atomic.Int32
+}
+
+func (s *stub) Lookup(ip string) (*Location, error) {
+	s.calls.Add(1)
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.location == nil {
+		return &Location{}, nil
+	}
+	loc := *s.location
+	return &loc, nil
+}
+
+var errBroken = errors.New("broken database")
+
+func country(code string) *Location {
+	return &Location{CountryCode: code}
+}
+
+func names(m interface{}) string {
+	var list []string
+	switch m := m.(type) {
+	case map[string]*Location:
+		for name := range m {
+			list = append(list, name)
+		}
+	case map[string]error:
+		for name := range m {
+			list = append(list, name)
+		}
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func TestAggregatorLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AggregatorConfig
+		sources map[string]*stub
+		// priorities and timeouts of the sources, by name
+		priority map[string]int
+		timeout  map[string]time.Duration
+		options  LookupOptions
+
+		locations string
+		errors    map[string]error
+		// unasked sources must not be looked up at all
+		unasked string
+		// within bounds how long the lookup may take
+		within time.Duration
+	}{
+		{
+			name: "all",
+			sources: map[string]*stub{
+				"a": {location: country("US")},
+				"b": {},
+				"c": {err: errBroken},
+			},
+			locations: "a,b",
+			errors:    map[string]error{"c": errBroken},
+		},
+		{
+			name:   "first success",
+			config: AggregatorConfig{Policy: FirstSuccess},
+			sources: map[string]*stub{
+				"a": {},
+				"b": {err: errBroken},
+				"c": {location: country("US")},
+				"d": {location: country("GB")},
+			},
+			priority:  map[string]int{"a": 4, "b": 3, "c": 2, "d": 1},
+			locations: "a,c",
+			errors:    map[string]error{"b": errBroken},
+			unasked:   "d",
+		},
+		{
+			// IP2Location reports "-" for an address it has no country for
+			name:   "first success skips unknown countries",
+			config: AggregatorConfig{Policy: FirstSuccess},
+			sources: map[string]*stub{
+				"a": {location: country("-")},
+				"b": {location: country("US")},
+			},
+			priority:  map[string]int{"a": 2, "b": 1},
+			locations: "a,b",
+		},
+		{
+			name:   "quorum returns early",
+			config: AggregatorConfig{Policy: Quorum, Quorum: 2},
+			sources: map[string]*stub{
+				"a": {location: country("US")},
+				"b": {location: country("US")},
+				"c": {location: country("US"), delay: time.Second},
+			},
+			// c is still running and left out of both maps
+			locations: "a,b",
+			within:    500 * time.Millisecond,
+		},
+		{
+			name:   "quorum counts located sources",
+			config: AggregatorConfig{Policy: Quorum, Quorum: 2},
+			sources: map[string]*stub{
+				"a": {},
+				"b": {location: country("US")},
+				"c": {location: country("US"), delay: 20 * time.Millisecond},
+			},
+			locations: "a,b,c",
+		},
+		{
+			name:   "majority quorum",
+			config: AggregatorConfig{Policy: Quorum},
+			sources: map[string]*stub{
+				"a": {location: country("US")},
+				"b": {location: country("US"), delay: 20 * time.Millisecond},
+				"c": {location: country("US"), delay: time.Second},
+			},
+			locations: "a,b",
+			within:    500 * time.Millisecond,
+		},
+		{
+			name: "source timeout",
+			sources: map[string]*stub{
+				"a": {location: country("US")},
+				"b": {location: country("US"), delay: time.Second},
+			},
+			timeout:   map[string]time.Duration{"b": 20 * time.Millisecond},
+			locations: "a",
+			errors:    map[string]error{"b": ErrTimeout},
+			within:    500 * time.Millisecond,
+		},
+		{
+			name:   "lookup timeout",
+			config: AggregatorConfig{Timeout: 20 * time.Millisecond},
+			sources: map[string]*stub{
+				"a": {location: country("US")},
+				"b": {location: country("US"), delay: time.Second},
+			},
+			locations: "a",
+			errors:    map[string]error{"b": ErrTimeout},
+			within:    500 * time.Millisecond,
+		},
+		{
+			name:   "first success timeout moves on",
+			config: AggregatorConfig{Policy: FirstSuccess},
+			sources: map[string]*stub{
+				"a": {location: country("US"), delay: time.Second},
+				"b": {location: country("GB")},
+			},
+			priority:  map[string]int{"a": 2, "b": 1},
+			timeout:   map[string]time.Duration{"a": 20 * time.Millisecond},
+			locations: "b",
+			errors:    map[string]error{"a": ErrTimeout},
+			within:    500 * time.Millisecond,
+		},
+		{
+			name: "selected sources",
+			sources: map[string]*stub{
+				"a": {location: country("US")},
+				"b": {location: country("GB")},
+			},
+			options:   LookupOptions{Sources: []string{"b"}},
+			locations: "b",
+			unasked:   "a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sources []Source
+			for name, s := range tt.sources {
+				sources = append(sources, Source{Service: s, Name: name, Priority: tt.priority[name], Timeout: tt.timeout[name]})
+			}
+			agg, err := NewAggregator(tt.config, sources...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			result := agg.Lookup(context.Background(), "192.0.2.1", tt.options)
+			if tt.within > 0 {
+				if elapsed := time.Since(start); elapsed > tt.within {
+					t.Errorf("Lookup() took %v", elapsed)
+				}
+			}
+
+			if got := names(result.Locations); got != tt.locations {
+				t.Errorf("Locations from %q, want %q", got, tt.locations)
+			}
+			if got, want := names(result.Errors), names(tt.errors); got != want {
+				t.Errorf("Errors from %q, want %q", got, want)
+			}
+			for name, want := range tt.errors {
+				if !errors.Is(result.Errors[name], want) {
+					t.Errorf("Errors[%s] = %v, want %v", name, result.Errors[name], want)
+				}
+			}
+			for _, name := range strings.Split(tt.unasked, ",") {
+				if s := tt.sources[name]; s != nil && s.calls.Load() != 0 {
+					t.Errorf("source %s was asked", name)
+				}
+			}
+		})
+	}
+}
+
+func TestAggregatorMerge(t *testing.T) {
+	maxmind := &Location{
+		Country: "United States", CountryCode: "US", CountryGeoNameID: 6252001,
+		ASN: &ASN{Number: 15169, Organization: "GOOGLE"},
+	}
+	tests := []struct {
+		name    string
+		primary *Location
+		other   *Location
+		want    Location
+	}{
+		{
+			// A source that disagrees on the country contributes nothing
+			// but the fields that do not depend on it
+			name:    "disagreeing country",
+			primary: maxmind,
+			other: &Location{
+				Country: "Canada", CountryCode: "CA", Region: "Ontario", RegionCode: "CA-ON", City: "Toronto",
+				Latitude: 43.7, Longitude: -79.4, TimeZone: "America/Toronto",
+				Anonymizer: &Anonymizer{Anonymous: true, VPN: true},
+			},
+			want: Location{
+				Country: "United States", CountryCode: "US", CountryGeoNameID: 6252001,
+				ASN:        &ASN{Number: 15169, Organization: "GOOGLE"},
+				Anonymizer: &Anonymizer{Anonymous: true, VPN: true},
+			},
+		},
+		{
+			name:    "agreeing country",
+			primary: maxmind,
+			other: &Location{
+				Country: "United States of America", CountryCode: "US", Region: "california", RegionCode: "US-CA",
+				City: "Mountain View", Latitude: 37.4, Longitude: -122.1, TimeZone: "America/Los_Angeles",
+			},
+			want: Location{
+				Country: "United States", CountryCode: "US", CountryGeoNameID: 6252001,
+				// The region keeps the source's name
+				Region: "california", RegionCode: "US-CA", City: "Mountain View",
+				Latitude: 37.4, Longitude: -122.1, TimeZone: "America/Los_Angeles",
+				ASN: &ASN{Number: 15169, Organization: "GOOGLE"},
+			},
+		},
+		{
+			// MaxMind's English name, not the dataset's local one
+			name:    "source region name",
+			primary: &Location{Country: "Germany", CountryCode: "DE", Region: "Bavaria", RegionCode: "DE-BY"},
+			other:   &Location{Country: "Germany", CountryCode: "DE"},
+			want:    Location{Country: "Germany", CountryCode: "DE", Region: "Bavaria", RegionCode: "DE-BY"},
+		},
+		{
+			// The dataset only names a region the source left unnamed
+			name:    "region named by the dataset",
+			primary: &Location{Country: "Germany", CountryCode: "DE", Region: "-", RegionCode: "DE-BY"},
+			other:   &Location{Country: "Germany", CountryCode: "DE"},
+			want:    Location{Country: "Germany", CountryCode: "DE", Region: "Bayern", RegionCode: "DE-BY"},
+		},
+		{
+			name:    "unknown primary country",
+			primary: &Location{Country: "-", CountryCode: "-", City: "-"},
+			other:   &Location{Country: "Germany", CountryCode: "DE", City: "Berlin", TimeZone: "+01:00"},
+			// "+01:00" is not an IANA name
+			want: Location{Country: "Germany", CountryCode: "DE", City: "Berlin"},
+		},
+		{
+			// "UTC" names no place but is still a zone
+			name:    "UTC time zone",
+			primary: &Location{Country: "-", CountryCode: "-"},
+			other:   &Location{Country: "Germany", CountryCode: "DE", TimeZone: "UTC"},
+			want:    Location{Country: "Germany", CountryCode: "DE", TimeZone: "UTC"},
+		},
+		{
+			name:    "higher priority wins",
+			primary: &Location{Country: "France", CountryCode: "FR", City: "Paris"},
+			other:   &Location{Country: "Spain", CountryCode: "ES", City: "Madrid"},
+			want:    Location{Country: "France", CountryCode: "FR", City: "Paris"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg, err := NewAggregator(AggregatorConfig{},
+				Source{Service: &stub{location: tt.other}, Name: "other"},
+				Source{Service: &stub{location: tt.primary}, Name: "primary", Priority: 1},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := agg.Lookup(context.Background(), "192.0.2.1", LookupOptions{}).Merged
+			if got == nil {
+				t.Fatal("Merged is nil")
+			}
+			if !equalLocations(got, &tt.want) {
+				t.Errorf("Merged = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no answers", func(t *testing.T) {
+		agg, err := NewAggregator(AggregatorConfig{}, Source{Service: &stub{err: errBroken}, Name: "a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if merged := agg.Lookup(context.Background(), "192.0.2.1", LookupOptions{}).Merged; merged != nil {
+			t.Errorf("Merged = %+v without answers", merged)
+		}
+	})
+}
+
+// equalLocations compares locations, following the ASN and Anonymizer
+// pointers
+func equalLocations(a, b *Location) bool {
+	if (a.ASN == nil) != (b.ASN == nil) || a.ASN != nil && *a.ASN != *b.ASN {
+		return false
+	}
+	if (a.Anonymizer == nil) != (b.Anonymizer == nil) || a.Anonymizer != nil && *a.Anonymizer != *b.Anonymizer {
+		return false
+	}
+	x, y := *a, *b
+	x.ASN, x.Anonymizer, y.ASN, y.Anonymizer = nil, nil, nil, nil
+	return x == y
+}
+
+func TestAggregatorReconcile(t *testing.T) {
+	maxmind := &Location{
+		CountryCode: "US", CountryGeoNameID: 6252001,
+		RegionCode: "US-CA", RegionGeoNameID: 5332921,
+		City: "Mountain View", CityGeoNameID: 5375480,
+	}
+	tests := []struct {
+		name                    string
+		other                   *Location
+		country, region, cityID uint
+	}{
+		{
+			name:    "same city",
+			other:   &Location{CountryCode: "US", RegionCode: "US-CA", City: "MOUNTAIN VIEW"},
+			country: 6252001, region: 5332921, cityID: 5375480,
+		},
+		{
+			name:    "other city",
+			other:   &Location{CountryCode: "US", RegionCode: "US-CA", City: "San Jose"},
+			country: 6252001, region: 5332921,
+		},
+		{
+			name:    "other region",
+			other:   &Location{CountryCode: "US", RegionCode: "US-NV", City: "Mountain View"},
+			country: 6252001,
+		},
+		{
+			name:  "other country",
+			other: &Location{CountryCode: "CA", RegionCode: "US-CA", City: "Mountain View"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The source with the IDs has the lower priority, which does
+			// not matter for reconciling
+			agg, err := NewAggregator(AggregatorConfig{},
+				Source{Service: &stub{location: maxmind}, Name: string(MaxMindProvider)},
+				Source{Service: &stub{location: tt.other}, Name: string(IP2LocationProvider), Priority: 1},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := agg.Lookup(context.Background(), "192.0.2.1", LookupOptions{})
+			got := result.Locations[string(IP2LocationProvider)]
+			if got.CountryGeoNameID != tt.country || got.RegionGeoNameID != tt.region || got.CityGeoNameID != tt.cityID {
+				t.Errorf("IDs = %d, %d, %d; want %d, %d, %d", got.CountryGeoNameID, got.RegionGeoNameID, got.CityGeoNameID, tt.country, tt.region, tt.cityID)
+			}
+			// The source with the IDs is left alone
+			if ref := result.Locations[string(MaxMindProvider)]; *ref != *maxmind {
+				t.Errorf("MaxMind result changed to %+v", ref)
+			}
+		})
+	}
+}
+
+func TestNewAggregatorErrors(t *testing.T) {
+	tests := map[string][]Source{
+		"no service": {{Name: "a"}},
+		"no name":    {{Service: &stub{}}},
+		"duplicate":  {{Service: &stub{}, Name: "a"}, {Service: &stub{}, Name: "a"}},
+	}
+	for name, sources := range tests {
+		if _, err := NewAggregator(AggregatorConfig{}, sources...); err == nil {
+			t.Errorf("%s: NewAggregator() succeeded", name)
+		}
+	}
+	if _, err := NewAggregator(AggregatorConfig{Policy: Quorum, Quorum: 2}, Source{Service: &stub{}, Name: "a"}); err == nil {
+		t.Error("NewAggregator() accepted a quorum above the sources")
+	}
+
+	agg, err := NewAggregator(AggregatorConfig{}, Source{Service: &stub{}, Name: "low"}, Source{Service: &stub{}, Name: "high", Priority: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(agg.Sources(), ","); got != "high,low" {
+		t.Errorf("Sources() = %s, want them by priority", got)
+	}
+}
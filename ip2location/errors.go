@@ -4,5 +4,7 @@ import "errors"
 
 var (
 	ErrInvalidIP       = errors.New("invalid IP address")
+	ErrAmbiguousIP     = errors.New("ambiguous IP address notation")
 	ErrInvalidProvider = errors.New("invalid provider")
+	ErrTimeout         = errors.New("lookup timed out")
 ) 
\ No newline at end of file
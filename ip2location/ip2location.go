@@ -2,6 +2,9 @@ package ip2location
 
 import (
 	"net"
+	"strconv"
+
+	"github.com/imnitish-dev/ip2location/countries"
 )
 
 func (s *Service) lookupIP2Location(ip net.IP) (*Location, error) {
@@ -17,6 +20,18 @@ func (s *Service) lookupIP2Location(ip net.IP) (*Location, error) {
 		Latitude:    float64(results.Latitude),
 		Longitude:   float64(results.Longitude),
 		CountryCode: results.Country_short,
+		TimeZone:    results.Timezone,
+	}
+
+	// IP2Location reports region names only; its names are those of the
+	// ISO 3166-2 dataset
+	if subdivision, ok := countries.FindSubdivision(results.Country_short, results.Region); ok {
+		location.RegionCode = subdivision.Code
+	}
+
+	// Only DB26 has the ASN columns; other files fill them with a notice
+	if number, err := strconv.ParseUint(results.Asn, 10, 32); err == nil && number != 0 {
+		location.ASN = &ASN{Number: uint(number), Organization: results.As}
 	}
 
 	return location, nil
@@ -10,18 +10,55 @@ func (s *Service) lookupMaxMind(ip net.IP) (*Location, error) {
 		return nil, err
 	}
 
-	var region string
+	var (
+		region          string
+		regionCode      string
+		regionGeoNameID uint
+	)
 	if len(record.Subdivisions) > 0 {
-		region = record.Subdivisions[0].Names["en"]
+		subdivision := record.Subdivisions[0]
+		region = subdivision.Names["en"]
+		regionGeoNameID = subdivision.GeoNameID
+		if record.Country.IsoCode != "" && subdivision.IsoCode != "" {
+			regionCode = record.Country.IsoCode + "-" + subdivision.IsoCode
+		}
 	}
 
 	location := &Location{
-		Country:     record.Country.Names["en"],
-		City:        record.City.Names["en"],
-		Region:      region,
-		Latitude:    record.Location.Latitude,
-		Longitude:   record.Location.Longitude,
-		CountryCode: record.Country.IsoCode,
+		Country:        record.Country.Names["en"],
+		City:           record.City.Names["en"],
+		Region:         region,
+		Latitude:       record.Location.Latitude,
+		Longitude:      record.Location.Longitude,
+		CountryCode:    record.Country.IsoCode,
+		AccuracyRadius: record.Location.AccuracyRadius,
+		TimeZone:       record.Location.TimeZone,
+
+		RegionCode:       regionCode,
+		CountryGeoNameID: record.Country.GeoNameID,
+		RegionGeoNameID:  regionGeoNameID,
+		CityGeoNameID:    record.City.GeoNameID,
+	}
+
+	if s.asnDB != nil {
+		if asn, err := s.asnDB.ASN(ip); err == nil && asn.AutonomousSystemNumber != 0 {
+			location.ASN = &ASN{
+				Number:       asn.AutonomousSystemNumber,
+				Organization: asn.AutonomousSystemOrganization,
+			}
+		}
+	}
+	if s.anonymousDB != nil {
+		if anonymous, err := s.anonymousDB.AnonymousIP(ip); err == nil {
+			location.Anonymizer = &Anonymizer{
+				Anonymous:        anonymous.IsAnonymous,
+				VPN:              anonymous.IsAnonymousVPN,
+				Hosting:          anonymous.IsHostingProvider,
+				PublicProxy:      anonymous.IsPublicProxy,
+				ResidentialProxy: anonymous.IsResidentialProxy,
+				TorExitNode:      anonymous.IsTorExitNode,
+			}
+		}
 	}
 
 	return location, nil
@@ -0,0 +1,127 @@
+package ip2location
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+)
+
+// writeMMDB writes a database of dbType with a record per network
+func writeMMDB(t *testing.T, dbType string, records map[string]mmdbtype.Map) string {
+	t.Helper()
+	w, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: dbType, RecordSize: 28, IncludeReservedNetworks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for cidr, record := range records {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Insert(network, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), dbType+".mmdb")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := w.WriteTo(f); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func city(code, name string, geoNameID uint32) mmdbtype.Map {
+	return mmdbtype.Map{
+		"country": mmdbtype.Map{
+			"iso_code":   mmdbtype.String(code),
+			"geoname_id": mmdbtype.Uint32(geoNameID),
+			"names":      mmdbtype.Map{"en": mmdbtype.String(name)},
+		},
+	}
+}
+
+func TestMaxMindASNAndAnonymousIP(t *testing.T) {
+	service, err := NewService(MaxMindProvider, writeMMDB(t, "GeoLite2-City", map[string]mmdbtype.Map{
+		"198.51.100.0/24": city("US", "United States", 6252001),
+		"203.0.113.0/24":  city("GB", "United Kingdom", 2635167),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer service.Close()
+
+	// Without the extra databases neither field is reported
+	loc, err := service.Lookup("198.51.100.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.ASN != nil || loc.Anonymizer != nil {
+		t.Fatalf("Lookup() = %+v, %+v without the databases", loc.ASN, loc.Anonymizer)
+	}
+
+	asnPath := writeMMDB(t, "GeoLite2-ASN", map[string]mmdbtype.Map{
+		"198.51.100.0/24": {
+			"autonomous_system_number":       mmdbtype.Uint32(64500),
+			"autonomous_system_organization": mmdbtype.String("Example Networks"),
+		},
+	})
+	anonymousPath := writeMMDB(t, "GeoIP2-Anonymous-IP", map[string]mmdbtype.Map{
+		"198.51.100.0/25": {
+			"is_anonymous":     mmdbtype.Bool(true),
+			"is_anonymous_vpn": mmdbtype.Bool(true),
+		},
+	})
+	if err := service.OpenASN(asnPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.OpenAnonymousIP(anonymousPath); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ip         string
+		asn        *ASN
+		anonymizer *Anonymizer
+	}{
+		{"198.51.100.1", &ASN{Number: 64500, Organization: "Example Networks"}, &Anonymizer{Anonymous: true, VPN: true}},
+		{"198.51.100.200", &ASN{Number: 64500, Organization: "Example Networks"}, &Anonymizer{}},
+		{"203.0.113.1", nil, &Anonymizer{}},
+	}
+	for _, tt := range tests {
+		loc, err := service.Lookup(tt.ip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (loc.ASN == nil) != (tt.asn == nil) || loc.ASN != nil && *loc.ASN != *tt.asn {
+			t.Errorf("Lookup(%s).ASN = %+v, want %+v", tt.ip, loc.ASN, tt.asn)
+		}
+		if loc.Anonymizer == nil || *loc.Anonymizer != *tt.anonymizer {
+			t.Errorf("Lookup(%s).Anonymizer = %+v, want %+v", tt.ip, loc.Anonymizer, tt.anonymizer)
+		}
+	}
+}
+
+func TestOpenASNChecksDatabaseType(t *testing.T) {
+	cityPath := writeMMDB(t, "GeoLite2-City", map[string]mmdbtype.Map{"198.51.100.0/24": city("US", "United States", 6252001)})
+	service, err := NewService(MaxMindProvider, cityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer service.Close()
+
+	if err := service.OpenASN(cityPath); err == nil {
+		t.Error("OpenASN() accepted a City database")
+	}
+	if err := service.OpenAnonymousIP(cityPath); err == nil {
+		t.Error("OpenAnonymousIP() accepted a City database")
+	}
+}
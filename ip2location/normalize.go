@@ -0,0 +1,24 @@
+package ip2location
+
+import "github.com/imnitish-dev/ip2location/countries"
+
+// Reconcile gives the IP2Location result the GeoNames IDs of the MaxMind
+// result wherever the two agree: the country when the country codes match,
+// the region when the ISO 3166-2 codes match, and the city when, in
+// addition, the city names match. Only MaxMind records GeoNames IDs.
+func Reconcile(maxmind, ip2loc *Location) {
+	if maxmind == nil || ip2loc == nil || maxmind.CountryCode == "" || maxmind.CountryCode != ip2loc.CountryCode {
+		return
+	}
+	ip2loc.CountryGeoNameID = maxmind.CountryGeoNameID
+
+	if maxmind.RegionCode != ip2loc.RegionCode {
+		return
+	}
+	if ip2loc.RegionCode != "" {
+		ip2loc.RegionGeoNameID = maxmind.RegionGeoNameID
+	}
+	if maxmind.City != "" && countries.Fold(maxmind.City) == countries.Fold(ip2loc.City) {
+		ip2loc.CityGeoNameID = maxmind.CityGeoNameID
+	}
+}
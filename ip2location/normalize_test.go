@@ -0,0 +1,78 @@
+package ip2location
+
+import "testing"
+
+func TestReconcile(t *testing.T) {
+	maxmind := func() *Location {
+		return &Location{
+			Country: "United States", CountryCode: "US", CountryGeoNameID: 6252001,
+			Region: "California", RegionCode: "US-CA", RegionGeoNameID: 5332921,
+			City: "Mountain View", CityGeoNameID: 5375480,
+		}
+	}
+	tests := []struct {
+		name    string
+		maxmind *Location
+		ip2loc  Location
+		want    Location
+	}{
+		{
+			name:    "all agree",
+			maxmind: maxmind(),
+			ip2loc:  Location{CountryCode: "US", Region: "California", RegionCode: "US-CA", City: "MOUNTAIN VIEW"},
+			want: Location{
+				CountryCode: "US", CountryGeoNameID: 6252001,
+				Region: "California", RegionCode: "US-CA", RegionGeoNameID: 5332921,
+				City: "MOUNTAIN VIEW", CityGeoNameID: 5375480,
+			},
+		},
+		{
+			name:    "different city",
+			maxmind: maxmind(),
+			ip2loc:  Location{CountryCode: "US", RegionCode: "US-CA", City: "Sunnyvale"},
+			want:    Location{CountryCode: "US", CountryGeoNameID: 6252001, RegionCode: "US-CA", RegionGeoNameID: 5332921, City: "Sunnyvale"},
+		},
+		{
+			// The same city name in another region is another city
+			name:    "different region",
+			maxmind: maxmind(),
+			ip2loc:  Location{CountryCode: "US", RegionCode: "US-NY", City: "Mountain View"},
+			want:    Location{CountryCode: "US", CountryGeoNameID: 6252001, RegionCode: "US-NY", City: "Mountain View"},
+		},
+		{
+			name:    "unresolved region",
+			maxmind: maxmind(),
+			ip2loc:  Location{CountryCode: "US", Region: "Kalifornien", City: "Mountain View"},
+			want:    Location{CountryCode: "US", CountryGeoNameID: 6252001, Region: "Kalifornien", City: "Mountain View"},
+		},
+		{
+			name:    "different country",
+			maxmind: maxmind(),
+			ip2loc:  Location{CountryCode: "CA", RegionCode: "US-CA", City: "Mountain View"},
+			want:    Location{CountryCode: "CA", RegionCode: "US-CA", City: "Mountain View"},
+		},
+		{
+			name:    "unlocated maxmind",
+			maxmind: &Location{},
+			ip2loc:  Location{CountryCode: "US", City: "Mountain View"},
+			want:    Location{CountryCode: "US", City: "Mountain View"},
+		},
+		{
+			name:   "no maxmind",
+			ip2loc: Location{CountryCode: "US"},
+			want:   Location{CountryCode: "US"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.ip2loc
+			Reconcile(tt.maxmind, &got)
+			if !equalLocations(&got, &tt.want) {
+				t.Errorf("Reconcile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// A nil IP2Location result is ignored
+	Reconcile(maxmind(), nil)
+}
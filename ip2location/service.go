@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/ip2location/ip2location-go/v9"
@@ -17,6 +18,47 @@ type Location struct {
 	Latitude    float64 `json:"latitude"`
 	Longitude   float64 `json:"longitude"`
 	CountryCode string  `json:"country_code"`
+
+	// AccuracyRadius is the radius in kilometres around the coordinates in
+	// which the address is likely to be; only MaxMind reports it
+	AccuracyRadius uint16 `json:"accuracy_radius,omitempty"`
+
+	// TimeZone is the time zone field of the database. MaxMind reports IANA
+	// names; IP2Location databases hold a UTC offset or, when they lack
+	// the field, a placeholder. It is only part of /v2 responses.
+	TimeZone string `json:"-"`
+
+	// Normalized identifiers for joining the results of both providers,
+	// only part of /v2 responses. RegionCode is the ISO 3166-2 code, such
+	// as US-CA; GeoNames IDs are zero when unknown.
+	RegionCode       string `json:"-"`
+	CountryGeoNameID uint   `json:"-"`
+	RegionGeoNameID  uint   `json:"-"`
+	CityGeoNameID    uint   `json:"-"`
+
+	// ASN is the autonomous system announcing the address and Anonymizer
+	// how the address hides its user. They are nil when the provider's
+	// databases do not report them, and only part of /v2 responses.
+	ASN        *ASN        `json:"-"`
+	Anonymizer *Anonymizer `json:"-"`
+}
+
+// ASN is an autonomous system
+type ASN struct {
+	Number       uint
+	Organization string
+}
+
+// Anonymizer flags addresses of anonymizing services. All flags are false
+// for an address the database knows nothing about.
+type Anonymizer struct {
+	// Anonymous is set when any of the other flags is, except Hosting
+	Anonymous        bool
+	VPN              bool
+	Hosting          bool
+	PublicProxy      bool
+	ResidentialProxy bool
+	TorExitNode      bool
 }
 
 type Provider string
@@ -31,6 +73,10 @@ type Service struct {
 	ip2locDB    *ip2location.DB
 	provider    Provider
 	mu          sync.RWMutex
+
+	// Optional MaxMind databases, see OpenASN and OpenAnonymousIP
+	asnDB       *geoip2.Reader
+	anonymousDB *geoip2.Reader
 }
 
 // NewService creates a new IP2Location service
@@ -65,6 +111,56 @@ func NewService(provider Provider, dbPath string) (*Service, error) {
 	return s, nil
 }
 
+// Provider returns the kind of database the service reads
+func (s *Service) Provider() Provider {
+	return s.provider
+}
+
+// OpenASN adds a GeoLite2-ASN or GeoIP2-ISP database to a MaxMind
+// service, so that its locations report their autonomous system
+func (s *Service) OpenASN(dbPath string) error {
+	return s.openMaxMindExtra(dbPath, &s.asnDB, "GeoLite2-ASN", "GeoIP2-ISP")
+}
+
+// OpenAnonymousIP adds a GeoIP2-Anonymous-IP database to a MaxMind service,
+// so that its locations report whether the address is a VPN, proxy or Tor
+// exit node
+func (s *Service) OpenAnonymousIP(dbPath string) error {
+	return s.openMaxMindExtra(dbPath, &s.anonymousDB, "GeoIP2-Anonymous-IP")
+}
+
+func (s *Service) openMaxMindExtra(dbPath string, db **geoip2.Reader, types ...string) error {
+	if s.provider != MaxMindProvider {
+		return ErrInvalidProvider
+	}
+
+	reader, err := geoip2.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	if dbType := reader.Metadata().DatabaseType; !contains(types, dbType) {
+		reader.Close()
+		return fmt.Errorf("%s is a %s database, not %s", dbPath, dbType, strings.Join(types, " or "))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if *db != nil {
+		(*db).Close()
+	}
+	*db = reader
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) Close() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -72,6 +168,12 @@ func (s *Service) Close() {
 	if s.maxmindDB != nil {
 		s.maxmindDB.Close()
 	}
+	if s.asnDB != nil {
+		s.asnDB.Close()
+	}
+	if s.anonymousDB != nil {
+		s.anonymousDB.Close()
+	}
 	if s.ip2locDB != nil {
 		s.ip2locDB.Close()
 	}
@@ -81,10 +183,11 @@ func (s *Service) Lookup(ipStr string) (*Location, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	ip := net.ParseIP(ipStr)
-	if ip == nil {
-		return nil, ErrInvalidIP
+	addr, err := ParseAddr(ipStr)
+	if err != nil {
+		return nil, err
 	}
+	ip := net.IP(addr.AsSlice())
 
 	switch s.provider {
 	case MaxMindProvider:
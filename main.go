@@ -2,17 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net"
-	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
-
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/goccy/go-json"
@@ -20,6 +17,11 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/imnitish-dev/ip2location/ip2location"
 	pb "github.com/imnitish-dev/ip2location/proto"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"github.com/imnitish-dev/ip2location/resolver"
+	"github.com/imnitish-dev/ip2location/rules"
+	"github.com/imnitish-dev/ip2location/timezone"
+	"github.com/imnitish-dev/ip2location/useragent"
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 )
@@ -31,6 +33,54 @@ type Config struct {
 	MaxMindDBPath   string
 	IP2LocationPath string
 	GRPCPort        string
+
+	// Optional MaxMind GeoLite2-ASN and GeoIP2-Anonymous-IP databases that
+	// add the autonomous system and anonymizer flags to MaxMind results
+	MaxMindASNPath         string
+	MaxMindAnonymousIPPath string
+
+	// ProxyProtocol enables PROXY protocol v1/v2 on both listeners. Headers
+	// are only honoured from ProxyProtocolCIDRs.
+	ProxyProtocol      bool
+	ProxyProtocolCIDRs []string
+
+	// Egress IP discovery for requests arriving from a local address
+	PublicIPMode      string
+	PublicIP          string
+	PublicIPEndpoints []string
+	PublicIPTTL       time.Duration
+	PublicIPTimeout   time.Duration
+
+	// DNS server ("host:port") and timeout for hostname lookups. An empty
+	// server uses the system resolver configuration.
+	DNSResolver string
+	DNSTimeout  time.Duration
+
+	// User-agent rules file (ua-parser regexes.yaml format) and cache size.
+	// An empty path uses the embedded rules.
+	UARulesPath string
+	UACacheSize int
+
+	// VerifyBots checks claimed search-engine bots with forward-confirmed
+	// reverse DNS
+	VerifyBots       bool
+	BotVerifyTimeout time.Duration
+
+	// LookupPolicy is all, first_success or quorum: how the providers are
+	// asked and how many must locate an address (LookupQuorum, a majority
+	// when zero) before a lookup returns. LookupTimeout bounds it.
+	LookupPolicy  string
+	LookupTimeout time.Duration
+	LookupQuorum  int
+
+	// Geofencing rules file for /v2/decide, checked for changes every
+	// RulesReloadInterval. An empty path disables decisions.
+	RulesPath           string
+	RulesReloadInterval time.Duration
+
+	// Deprecation and Sunset dates announced by the unversioned routes
+	UnversionedDeprecation time.Time
+	UnversionedSunset      time.Time
 }
 
 // loadConfig loads the configuration from environment variables
@@ -58,6 +108,44 @@ func loadConfig() (*Config, error) {
 		MaxMindDBPath:   getEnv("MAXMIND_DB_PATH", filepath.Join(workDir, "MaxMind.mmdb")),
 		IP2LocationPath: getEnv("IP2LOCATION_DB_PATH", filepath.Join(workDir, "IP2LOCATION.BIN")),
 		GRPCPort:        getEnv("GRPC_PORT", "50051"),
+
+		MaxMindASNPath:         getEnv("MAXMIND_ASN_DB_PATH", ""),
+		MaxMindAnonymousIPPath: getEnv("MAXMIND_ANONYMOUS_IP_DB_PATH", ""),
+
+		ProxyProtocol:      getEnvBool("PROXY_PROTOCOL", false),
+		ProxyProtocolCIDRs: getEnvList("PROXY_PROTOCOL_ALLOWED_CIDRS"),
+
+		PublicIPMode:      getEnv("PUBLIC_IP_MODE", PublicIPModeHTTP),
+		PublicIP:          getEnv("PUBLIC_IP", ""),
+		PublicIPEndpoints: getEnvList("PUBLIC_IP_ENDPOINTS"),
+		PublicIPTTL:       getEnvDuration("PUBLIC_IP_TTL", 10*time.Minute),
+		PublicIPTimeout:   getEnvDuration("PUBLIC_IP_TIMEOUT", 3*time.Second),
+
+		DNSResolver: getEnv("DNS_RESOLVER", ""),
+		DNSTimeout:  getEnvDuration("DNS_TIMEOUT", 2*time.Second),
+
+		UARulesPath: getEnv("UA_RULES_PATH", ""),
+		UACacheSize: getEnvInt("UA_CACHE_SIZE", useragent.DefaultCacheSize),
+
+		VerifyBots:       getEnvBool("VERIFY_BOTS", false),
+		BotVerifyTimeout: getEnvDuration("BOT_VERIFY_TIMEOUT", 2*time.Second),
+
+		LookupPolicy:  getEnv("LOOKUP_POLICY", "all"),
+		LookupTimeout: getEnvDuration("LOOKUP_TIMEOUT", ip2location.DefaultTimeout),
+		LookupQuorum:  getEnvInt("LOOKUP_QUORUM", 0),
+
+		RulesPath:           getEnv("RULES_PATH", ""),
+		RulesReloadInterval: getEnvDuration("RULES_RELOAD_INTERVAL", 10*time.Second),
+
+		UnversionedDeprecation: getEnvTime("UNVERSIONED_DEPRECATION", defaultUnversionedDeprecation),
+		UnversionedSunset:      getEnvTime("UNVERSIONED_SUNSET", defaultUnversionedSunset),
+	}
+
+	if config.ProxyProtocol && len(config.ProxyProtocolCIDRs) == 0 {
+		return nil, fmt.Errorf("PROXY_PROTOCOL is enabled but PROXY_PROTOCOL_ALLOWED_CIDRS is empty")
+	}
+	if config.UnversionedSunset.Before(config.UnversionedDeprecation) {
+		return nil, fmt.Errorf("UNVERSIONED_SUNSET is before UNVERSIONED_DEPRECATION")
 	}
 
 	return config, nil
@@ -72,61 +160,240 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// getEnvBool parses a boolean environment variable or returns a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvInt parses an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration parses a duration environment variable or returns a default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvTime parses a date (2006-01-02) or RFC 3339 timestamp environment
+// variable or returns a default value
+func getEnvTime(key string, defaultValue time.Time) time.Time {
+	value := os.Getenv(key)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t
+	}
+	return defaultValue
+}
+
+// getEnvList splits a comma separated environment variable, dropping empty items
+func getEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // Response holds the API response structure
 type Response struct {
-	Message     string                `json:"message,omitempty"`
-	MaxMind     *ip2location.Location `json:"maxmind,omitempty"`
-	IP2Location *ip2location.Location `json:"ip2location,omitempty"`
-	DeviceBrowser DeviceInfo `json:"deviceBrowser,omitempty"`
-	Ip string `json:"ip,omitempty"`
+	Message       string                `json:"message,omitempty"`
+	MaxMind       *ip2location.Location `json:"maxmind,omitempty"`
+	IP2Location   *ip2location.Location `json:"ip2location,omitempty"`
+	DeviceBrowser DeviceInfo            `json:"deviceBrowser,omitempty"`
+	Ip            string                `json:"ip,omitempty"`
+	Host          string                `json:"host,omitempty"`
+	Addresses     []ResolvedAddress     `json:"addresses,omitempty"`
+
+	// Result is the lookup behind MaxMind and IP2Location; /v2 also
+	// shows its merged location
+	Result *ip2location.Result `json:"-"`
+
+	// Status is the HTTP status of a failed batch entry, had it been looked
+	// up on its own; /v2 names the entry's error after it
+	Status int `json:"-"`
+}
+
+// locatedResponse is the response to a lookup of ip. The v1 fields carry
+// the MaxMind and IP2Location results.
+func locatedResponse(ip string, result *ip2location.Result) Response {
+	return Response{
+		Ip:          ip,
+		MaxMind:     result.Locations[string(ip2location.MaxMindProvider)],
+		IP2Location: result.Locations[string(ip2location.IP2LocationProvider)],
+		Result:      result,
+	}
 }
 
 // App holds the application dependencies
 type App struct {
-	maxmindService *ip2location.Service
-	ip2locService  *ip2location.Service
-	fiber          *fiber.App
+	providers   *ip2location.Aggregator
+	publicIP    PublicIPResolver
+	resolver    *resolver.Resolver
+	ua          *useragent.Parser
+	botVerifier *useragent.BotVerifier
+	rules       *rules.Engine
+	stopRules   func()
+	fiber       *fiber.App
+
+	// Dates announced by the deprecated unversioned routes
+	unversionedDeprecation time.Time
+	unversionedSunset      time.Time
+
+	// openAPI is the generated OpenAPI document served at /openapi.json
+	openAPI []byte
 }
 
-// NewApp initializes the application
-func NewApp(maxmindPath, ip2locPath string) (*App, error) {
-	var app App
+// newAggregator opens the provider databases that are available and
+// combines them as LOOKUP_POLICY says, preferring MaxMind when merging
+func newAggregator(config *Config) (*ip2location.Aggregator, error) {
+	policy, err := ip2location.ParsePolicy(config.LookupPolicy)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := timezone.NewFinder()
+	if err != nil {
+		return nil, err
+	}
 
-	maxmindService, err := ip2location.NewService(ip2location.MaxMindProvider, maxmindPath)
+	var sources []ip2location.Source
+	maxmindService, err := ip2location.NewService(ip2location.MaxMindProvider, config.MaxMindDBPath)
 	if err != nil {
 		log.Printf("Warning: Failed to initialize MaxMind service: %v", err)
+	} else {
+		sources = append(sources, ip2location.Source{Service: maxmindService, Priority: 1})
+		if err := openMaxMindExtras(maxmindService, config); err != nil {
+			maxmindService.Close()
+			return nil, err
+		}
 	}
-	app.maxmindService = maxmindService
 
-	ip2locService, err := ip2location.NewService(ip2location.IP2LocationProvider, ip2locPath)
+	ip2locService, err := ip2location.NewService(ip2location.IP2LocationProvider, config.IP2LocationPath)
 	if err != nil {
 		log.Printf("Warning: Failed to initialize IP2Location service: %v", err)
+	} else {
+		sources = append(sources, ip2location.Source{Service: ip2locService})
+	}
+
+	// Only proceed if at least one service is initialized
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("failed to initialize both MaxMind and IP2Location services")
+	}
+
+	aggregator, err := ip2location.NewAggregator(ip2location.AggregatorConfig{
+		Timeout:   config.LookupTimeout,
+		Policy:    policy,
+		Quorum:    config.LookupQuorum,
+		TimeZones: zones,
+	}, sources...)
+	if err != nil {
+		for _, source := range sources {
+			source.Service.(*ip2location.Service).Close()
+		}
+		return nil, err
+	}
+	return aggregator, nil
+}
+
+// openMaxMindExtras adds the optional ASN and anonymous IP databases to
+// the MaxMind service. Unlike the main databases they are only opened when
+// configured, so failing to open them is an error.
+func openMaxMindExtras(service *ip2location.Service, config *Config) error {
+	if config.MaxMindASNPath != "" {
+		if err := service.OpenASN(config.MaxMindASNPath); err != nil {
+			return fmt.Errorf("failed to open MAXMIND_ASN_DB_PATH: %w", err)
+		}
+	}
+	if config.MaxMindAnonymousIPPath != "" {
+		if err := service.OpenAnonymousIP(config.MaxMindAnonymousIPPath); err != nil {
+			return fmt.Errorf("failed to open MAXMIND_ANONYMOUS_IP_DB_PATH: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewApp initializes the application
+func NewApp(config *Config) (*App, error) {
+	var app App
+
+	publicIP, err := newPublicIPResolver(config)
+	if err != nil {
+		return nil, err
+	}
+	app.publicIP = publicIP
+	app.resolver = resolver.New(config.DNSResolver, config.DNSTimeout)
+	app.unversionedDeprecation, app.unversionedSunset = config.UnversionedDeprecation, config.UnversionedSunset
+	if app.unversionedDeprecation.IsZero() {
+		app.unversionedDeprecation = defaultUnversionedDeprecation
+	}
+	if app.unversionedSunset.IsZero() {
+		app.unversionedSunset = defaultUnversionedSunset
+	}
+
+	app.ua, err = newUAParser(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.VerifyBots {
+		app.botVerifier = useragent.NewBotVerifier(app.resolver.NetResolver(), config.BotVerifyTimeout)
+	}
+
+	app.providers, err = newAggregator(config)
+	if err != nil {
+		return nil, err
+	}
+
+	app.rules, app.stopRules, err = newRules(config)
+	if err != nil {
+		app.providers.Close()
+		return nil, err
 	}
-	app.ip2locService = ip2locService
 
 	app.fiber = fiber.New(fiber.Config{
 		ErrorHandler:          errorHandler,
-		JSONEncoder:          json.Marshal,
-		JSONDecoder:          json.Unmarshal,
+		JSONEncoder:           json.Marshal,
+		JSONDecoder:           json.Unmarshal,
 		DisableStartupMessage: true,
 	})
 
-	// Only proceed if at least one service is initialized
-	if app.maxmindService == nil && app.ip2locService == nil {
-		return nil, fmt.Errorf("failed to initialize both MaxMind and IP2Location services")
+	app.setupRoutes()
+	rpcOperations, err := app.setupRPCRoutes()
+	if err != nil {
+		app.Close()
+		return nil, err
 	}
 
-	app.setupRoutes()
+	app.openAPI, err = buildOpenAPI(append(append([]operation{}, operations...), rpcOperations...))
+	if err != nil {
+		app.Close()
+		return nil, err
+	}
 	return &app, nil
 }
 
 // Close releases all resources
 func (a *App) Close() {
-	if a.maxmindService != nil {
-		a.maxmindService.Close()
+	if a.stopRules != nil {
+		a.stopRules()
 	}
-	if a.ip2locService != nil {
-		a.ip2locService.Close()
+	if a.providers != nil {
+		a.providers.Close()
 	}
 }
 
@@ -136,159 +403,158 @@ func (a *App) setupRoutes() {
 		Format: "${time} ${status} - ${latency} ${method} ${path}\n",
 	}))
 
-	// Define routes
-	a.fiber.Get("/lookup/:ip", a.handleIPLookup)
+	a.fiber.Use(requestClientHints)
+
+	// Define routes. /v1 keeps the original response shape and the
+	// unversioned routes are deprecated aliases of it.
+	a.apiRoutes(a.fiber.Group("/v1"), useVersion(apiV1))
+	v2 := a.fiber.Group("/v2")
+	a.apiRoutes(v2, useVersion(apiV2))
+	// Country metadata only exists in the v2 model, so there is neither a
+	// /v1 route nor an unversioned alias
+	v2.Get("/countries/:code", useVersion(apiV2), handleCountry)
+	v2.Post("/decide", useVersion(apiV2), requestTimeout, a.handleDecide)
+	// Decisions are new in v2, so /decide is not a deprecated /v1 alias
+	a.fiber.Post("/decide", useVersion(apiV2), requestTimeout, a.handleDecide)
 	a.fiber.Get("/health", handleHealth)
-	a.fiber.Get("/", a.handleIp)
+	a.fiber.Get("/openapi.json", a.handleOpenAPI)
+	a.fiber.Get("/docs", handleDocs)
+	a.fiber.Get("/docs/redoc.standalone.js", handleRedoc)
+	a.apiRoutes(a.fiber, a.deprecated)
 }
 
-func sanitizeIP(rawIp string) (string, error) {
-	ip, err := url.QueryUnescape(rawIp)
-	if err != nil {
+// apiRoutes registers the versioned API on router, behind middleware
+func (a *App) apiRoutes(router fiber.Router, middleware fiber.Handler) {
+	router.Get("/lookup/:ip", middleware, requestTimeout, a.handleIPLookup)
+	router.Post("/lookup", middleware, requestTimeout, a.handleBatchLookup)
+	router.Get("/ua", middleware, a.handleParseUA)
+	router.Post("/ua", middleware, a.handleParseUABatch)
+	router.Get("/", middleware, requestTimeout, a.handleIp)
+}
+
+// parseIP returns the canonical form of an address to geolocate, given in
+// any notation ip2location.ParseAddr accepts
+func parseIP(raw string) (string, error) {
+	addr, err := ip2location.ParseAddr(raw)
+	if errors.Is(err, ip2location.ErrAmbiguousIP) {
 		return "", err
 	}
-
-	// Retrieve IP parameter
-	ip = strings.TrimSpace(ip)
-	// Remove spaces
-	ip = strings.TrimSpace(ip)
-	
-	// URL encode the IP
-	ip = url.QueryEscape(ip)
-	
-	// Remove dashes and replace with dots
-	ip = strings.ReplaceAll(ip, "-", ".")
-	
-	// Validate IP address format
-	if net.ParseIP(ip) == nil {
+	if err != nil {
 		return "", fmt.Errorf("invalid IP address format")
 	}
-	
-	return ip, nil
+
+	return addr.String(), nil
 }
 
 func (a *App) handleIPLookup(c *fiber.Ctx) error {
-	ip, err := sanitizeIP(c.Params("ip"))
+	work, err := requestWork(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(Response{
+		return writeError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	// The path segment may be percent-encoded, as in fe80::1%25eth0
+	query, err := url.PathUnescape(c.Params("ip"))
+	if err != nil {
+		return render(c.Status(fiber.StatusBadRequest), Response{
 			Message: err.Error(),
 		})
 	}
-	maxmindLoc, ip2locLoc := a.lookupConcurrent(ip)
 
-	// If both lookups failed
-	if maxmindLoc == nil && ip2locLoc == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(Response{
+	ip, err := parseIP(query)
+	if err != nil {
+		if resolver.IsHostname(query) {
+			return a.handleHostLookup(c, query, work)
+		}
+		return render(c.Status(fiber.StatusBadRequest), Response{
+			Message: err.Error(),
+		})
+	}
+	result := a.lookup(c.UserContext(), ip, work)
+
+	// If every lookup failed
+	if work.providers() && len(result.Locations) == 0 {
+		return render(c.Status(fiber.StatusBadRequest), Response{
 			Message: "Failed to lookup IP address",
 		})
 	}
 
-	deviceBrowser := getDeviceInfo(c.Get("User-Agent"))
+	var deviceBrowser DeviceInfo
+	if work.device {
+		deviceBrowser = a.getDeviceInfo(c)
+	}
 
-	return c.JSON(Response{
-		MaxMind:     maxmindLoc,
-		IP2Location: ip2locLoc,
-		DeviceBrowser: deviceBrowser,
-	})
+	response := locatedResponse(ip, result)
+	response.DeviceBrowser = deviceBrowser
+	return render(c, response)
 }
 
-func (a *App) lookupConcurrent(ip string) (*ip2location.Location, *ip2location.Location) {
-	var (
-		wg          sync.WaitGroup
-		maxmindLoc  *ip2location.Location
-		ip2locLoc   *ip2location.Location
-		maxmindErr  error
-		ip2locErr   error
-	)
-
-	// Start both lookups concurrently
-	wg.Add(2)
-
-	// MaxMind lookup
-	go func() {
-		defer wg.Done()
-		if a.maxmindService != nil {
-			maxmindLoc, maxmindErr = a.maxmindService.Lookup(ip)
-		} else {
-			log.Printf("Warning: MaxMind service is not initialized")
-		}
-	}()
-
-	// IP2Location lookup
-	go func() {
-		defer wg.Done()
-		if a.ip2locService != nil {
-			ip2locLoc, ip2locErr = a.ip2locService.Lookup(ip)
-		} else {
-			log.Printf("Warning: IP2Location service is not initialized")
-		}
-	}()
-
-	// Wait with timeout
-	done := make(chan struct{})
-	go func() {
-		wg.Wait()
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		// Both lookups completed
-	case <-time.After(2 * time.Second):
-		// Timeout occurred, return whatever we have
-		log.Printf("Warning: Lookup timeout occurred")
+// lookup runs the providers work asks for through the aggregator and logs
+// the ones that failed
+func (a *App) lookup(ctx context.Context, ip string, work lookupWork) *ip2location.Result {
+	options := ip2location.LookupOptions{Sources: []string{}, TimeZones: work.timeZone}
+	if work.maxmind {
+		options.Sources = append(options.Sources, string(ip2location.MaxMindProvider))
 	}
-
-	// Log errors if any
-	if maxmindErr != nil && maxmindErr != ip2location.ErrInvalidIP {
-		log.Printf("MaxMind lookup error: %v", maxmindErr)
-	}
-	if ip2locErr != nil && ip2locErr != ip2location.ErrInvalidIP {
-		log.Printf("IP2Location lookup error: %v", ip2locErr)
+	if work.ip2location {
+		options.Sources = append(options.Sources, string(ip2location.IP2LocationProvider))
 	}
 
-	return maxmindLoc, ip2locLoc
+	result := a.providers.Lookup(ctx, ip, options)
+	for name, err := range result.Errors {
+		if err != ip2location.ErrInvalidIP {
+			log.Printf("%s lookup error: %v", name, err)
+		}
+	}
+	return result
 }
 
 func handleHealth(c *fiber.Ctx) error {
-	return c.JSON(Response{
+	return render(c, Response{
 		Message: "Service is healthy",
 	})
 }
 
 func (a *App) handleIp(c *fiber.Ctx) error {
+	work, err := requestWork(c)
+	if err != nil {
+		return writeError(c, fiber.StatusBadRequest, err.Error())
+	}
+
 	// Get client IP with fallback logic
 	ip := getClientIP(c)
-	
+
 	// Only proceed with external IP lookup if needed
 	if isLocalIP(ip) {
-		ip = getPublicIP()
+		publicIP, err := a.publicIP.PublicIP(c.UserContext())
+		if err != nil && !errors.Is(err, ErrPublicIPDisabled) {
+			log.Printf("Public IP discovery failed: %v", err)
+		}
+		ip = publicIP
 	}
-	
+
 	// If we couldn't determine the IP, return error
 	if ip == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(Response{
+		return render(c.Status(fiber.StatusBadRequest), Response{
 			Message: "Could not determine valid IP address",
 		})
 	}
 
-	// Concurrent lookup using existing IP
-	maxmindLoc, ip2locLoc := a.lookupConcurrent(ip)
+	result := a.lookup(c.UserContext(), ip, work)
 
-	if maxmindLoc == nil && ip2locLoc == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(Response{
+	if work.providers() && len(result.Locations) == 0 {
+		return render(c.Status(fiber.StatusBadRequest), Response{
 			Message: "Failed to lookup IP address",
 		})
 	}
 
-	deviceBrowser := getDeviceInfo(c.Get("User-Agent"))
+	var deviceBrowser DeviceInfo
+	if work.device {
+		deviceBrowser = a.getDeviceInfo(c)
+	}
 
-	return c.JSON(Response{
-		MaxMind:     maxmindLoc,
-		IP2Location: ip2locLoc,
-		DeviceBrowser: deviceBrowser,
-		Ip: ip,
-	})
+	response := locatedResponse(ip, result)
+	response.DeviceBrowser = deviceBrowser
+	return render(c, response)
 }
 
 // getClientIP attempts to get the real client IP from various headers
@@ -297,7 +563,7 @@ func getClientIP(c *fiber.Ctx) string {
 	if ip := c.IP(); !isLocalIP(ip) {
 		return ip
 	}
-	
+
 	// Try X-Forwarded-For
 	if forwardedFor := c.Get("x-forwarded-for"); forwardedFor != "" {
 		// Take the first IP if there are multiple
@@ -306,7 +572,7 @@ func getClientIP(c *fiber.Ctx) string {
 		}
 		return forwardedFor
 	}
-	
+
 	// Finally try X-Client-IP
 	return c.Get("x-client-ip")
 }
@@ -316,33 +582,12 @@ func isLocalIP(ip string) bool {
 	return ip == "" || ip == "127.0.0.1" || ip == "::1" || ip == "localhost"
 }
 
-// getPublicIP fetches the public IP using ipify with timeout
-func getPublicIP() string {
-	log.Println("Getting public IP from ipify")
-	client := &http.Client{
-		Timeout: 5 * time.Second,
-	}
-	
-	resp, err := client.Get("https://api.ipify.org")
-	if err != nil {
-		return ""
-	}
-	defer resp.Body.Close()
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return ""
-	}
-	
-	return string(body)
-}
-
 func errorHandler(c *fiber.Ctx, err error) error {
 	code := fiber.StatusInternalServerError
 	if e, ok := err.(*fiber.Error); ok {
 		code = e.Code
 	}
-	return c.Status(code).JSON(Response{
+	return render(c.Status(code), Response{
 		Message: err.Error(),
 	})
 }
@@ -353,40 +598,80 @@ type GRPCServer struct {
 	app *App
 }
 
-// LookupIP implements the gRPC lookup method
+// LookupIP implements the gRPC lookup method. When neither an IP nor a host
+// is given, the caller's own address is used. A field mask limits the
+// response, and the lookups run, to the selected fields.
 func (s *GRPCServer) LookupIP(ctx context.Context, req *pb.LookupRequest) (*pb.LookupResponse, error) {
-	maxmindLoc, ip2locLoc := s.app.lookupConcurrent(req.Ip)
-
 	response := &pb.LookupResponse{}
 
-	if maxmindLoc == nil && ip2locLoc == nil {
-		response.Message = "Failed to lookup IP address"
+	mask, err := protoFieldMask(req.FieldMask, response.ProtoReflect(), "message")
+	if err != nil {
+		response.Message = err.Error()
 		return response, nil
 	}
 
-	if maxmindLoc != nil {
-		response.Maxmind = &pb.Location{
-			Country:     maxmindLoc.Country,
-			City:       maxmindLoc.City,
-			Region:     maxmindLoc.Region,
-			Latitude:   maxmindLoc.Latitude,
-			Longitude:  maxmindLoc.Longitude,
-			CountryCode: maxmindLoc.CountryCode,
+	s.lookupIP(ctx, req, mask.work("device"), response)
+	pruneProto(response.ProtoReflect(), mask, "")
+	return response, nil
+}
+
+func (s *GRPCServer) lookupIP(ctx context.Context, req *pb.LookupRequest, work lookupWork, response *pb.LookupResponse) {
+	if req.Host != "" {
+		addresses, err := s.app.lookupHost(ctx, req.Host, work)
+		if err != nil {
+			response.Message = err.Error()
+			return
 		}
-	}
 
-	if ip2locLoc != nil {
-		response.Ip2Location = &pb.Location{
-			Country:     ip2locLoc.Country,
-			City:       ip2locLoc.City,
-			Region:     ip2locLoc.Region,
-			Latitude:   ip2locLoc.Latitude,
-			Longitude:  ip2locLoc.Longitude,
-			CountryCode: ip2locLoc.CountryCode,
+		response.Host = req.Host
+		response.Addresses = toPBAddresses(addresses)
+		if work.device {
+			response.Device = s.app.grpcDeviceInfo(ctx, req.UserAgent)
 		}
+		return
 	}
 
-	return response, nil
+	ip := req.Ip
+	if ip == "" {
+		ip = peerIP(ctx)
+	}
+
+	addr, err := ip2location.ParseAddr(ip)
+	if err != nil {
+		response.Message = err.Error()
+		return
+	}
+	response.Ip = addr.String()
+
+	result := s.app.lookup(ctx, response.Ip, work)
+
+	if work.providers() && len(result.Locations) == 0 {
+		response.Message = "Failed to lookup IP address"
+		return
+	}
+
+	response.Maxmind = toPBLocation(result.Locations[string(ip2location.MaxMindProvider)])
+	response.Ip2Location = toPBLocation(result.Locations[string(ip2location.IP2LocationProvider)])
+	if work.device {
+		response.Device = s.app.grpcDeviceInfo(ctx, req.UserAgent)
+	}
+}
+
+// toPBLocation converts a provider result to its protobuf form
+func toPBLocation(loc *ip2location.Location) *pb.Location {
+	if loc == nil {
+		return nil
+	}
+
+	return &pb.Location{
+		Country:        loc.Country,
+		City:           loc.City,
+		Region:         loc.Region,
+		Latitude:       loc.Latitude,
+		Longitude:      loc.Longitude,
+		CountryCode:    loc.CountryCode,
+		AccuracyRadius: uint32(loc.AccuracyRadius),
+	}
 }
 
 func main() {
@@ -397,7 +682,7 @@ func main() {
 	}
 
 	// Initialize application
-	app, err := NewApp(config.MaxMindDBPath, config.IP2LocationPath)
+	app, err := NewApp(config)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -406,13 +691,14 @@ func main() {
 	// Start gRPC server
 	go func() {
 		grpcAddr := fmt.Sprintf("%s:%s", config.Host, config.GRPCPort)
-		lis, err := net.Listen("tcp", grpcAddr)
+		lis, err := listen(grpcAddr, config)
 		if err != nil {
 			log.Fatalf("Failed to listen for gRPC: %v", err)
 		}
 
 		grpcServer := grpc.NewServer()
 		pb.RegisterIP2LocationServiceServer(grpcServer, &GRPCServer{app: app})
+		pbv2.RegisterIP2LocationServiceServer(grpcServer, &GRPCServerV2{app: app})
 
 		log.Printf("gRPC server starting on %s", grpcAddr)
 		if err := grpcServer.Serve(lis); err != nil {
@@ -424,7 +710,12 @@ func main() {
 	address := fmt.Sprintf("%s:%s", config.Host, config.Port)
 	log.Printf("HTTP server starting on %s!", address)
 
-	if err := app.fiber.Listen(address); err != nil {
+	ln, err := listen(address, config)
+	if err != nil {
 		log.Fatal(err)
 	}
-} 
\ No newline at end of file
+
+	if err := app.fiber.Listener(ln); err != nil {
+		log.Fatal(err)
+	}
+}
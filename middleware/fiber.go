@@ -0,0 +1,35 @@
+package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+// localsKey is the Locals key the Fiber handler stores the Info under
+const localsKey = "ip2location.middleware.info"
+
+// Fiber returns a Fiber handler that stores the Info of each request in
+// its Locals, read with FromFiber, and in its user context, read with
+// FromContext. Blocked requests end with fiber.ErrForbidden, which the
+// app's error handler renders.
+func (g *Geo) Fiber() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		info := g.Resolve(c.UserContext(), c.Context().RemoteAddr().String(), func(name string) string {
+			return c.Get(name)
+		})
+
+		switch action, url := g.Decide(info); action {
+		case Block:
+			return fiber.ErrForbidden
+		case Redirect:
+			return c.Redirect(url, fiber.StatusFound)
+		}
+
+		c.Locals(localsKey, info)
+		c.SetUserContext(NewContext(c.UserContext(), info))
+		return c.Next()
+	}
+}
+
+// FromFiber returns the Info the Fiber handler attached to c
+func FromFiber(c *fiber.Ctx) (*Info, bool) {
+	info, ok := c.Locals(localsKey).(*Info)
+	return info, ok
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestFiber(t *testing.T) {
+	// app.Test connects from 0.0.0.0, so the client is given as forwarded
+	// by that peer
+	g := newGeo(t, Config{
+		TrustedProxies: []string{"0.0.0.0/32"},
+		AllowCountries: []string{"US", "FR"},
+		Redirects:      map[string]string{"FR": "https://fr.example.com/"},
+	})
+	app := fiber.New()
+	app.Use(g.Fiber())
+	app.Get("/", func(c *fiber.Ctx) error {
+		info, ok := FromFiber(c)
+		if !ok {
+			t.Error("no Info in the Locals")
+			return nil
+		}
+		if fromContext, ok := FromContext(c.UserContext()); !ok || fromContext != info {
+			t.Error("the user context does not carry the Info")
+		}
+		if info.Device != nil {
+			t.Errorf("Info.Device = %+v without UserAgents", info.Device)
+		}
+		return c.SendString(info.IP + " " + info.CountryCode())
+	})
+
+	tests := []struct {
+		name      string
+		forwarded string
+		status    int
+		body      string
+		location  string
+	}{
+		{name: "passed", forwarded: "198.51.100.1", status: fiber.StatusOK, body: "198.51.100.1 US"},
+		{name: "6to4", forwarded: "2002:c633:6401::1", status: fiber.StatusOK, body: "198.51.100.1 US"},
+		{name: "not allowed", forwarded: "203.0.113.1", status: fiber.StatusForbidden},
+		{name: "unknown", forwarded: "10.0.0.1", status: fiber.StatusForbidden},
+		{name: "redirected", forwarded: "192.0.2.1", status: fiber.StatusFound, location: "https://fr.example.com/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("X-Forwarded-For", tt.forwarded)
+			res, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", res.StatusCode, tt.status)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if tt.body != "" && string(body) != tt.body {
+				t.Errorf("body = %q, want %q", body, tt.body)
+			}
+			if got := res.Header.Get("Location"); got != tt.location {
+				t.Errorf("Location = %q, want %q", got, tt.location)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import "net/http"
+
+// Handler wraps next so that it finds the Info of each request with
+// FromContext, and blocked or redirected requests never reach it
+func (g *Geo) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info := g.Resolve(r.Context(), r.RemoteAddr, r.Header.Get)
+
+		switch action, url := g.Decide(info); action {
+		case Block:
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		case Redirect:
+			http.Redirect(w, r, url, http.StatusFound)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), info)))
+	})
+}
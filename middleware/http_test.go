@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/imnitish-dev/ip2location/useragent"
+)
+
+func TestHandler(t *testing.T) {
+	g := newGeo(t, Config{
+		UserAgents:     useragent.NewDefault(0),
+		TrustedProxies: []string{"10.0.0.0/8"},
+		DenyCountries:  []string{"GB"},
+		Redirects:      map[string]string{"FR": "https://fr.example.com/"},
+	})
+	handler := g.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, ok := FromContext(r.Context())
+		if !ok {
+			t.Error("no Info in the request context")
+			return
+		}
+		if info.Device == nil || info.Device.Browser == nil {
+			t.Errorf("Info.Device = %+v, want a parsed browser", info.Device)
+		}
+		io.WriteString(w, info.IP+" "+info.CountryCode())
+	}))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		status     int
+		body       string
+		location   string
+	}{
+		{name: "passed", remoteAddr: "198.51.100.1:52000", status: http.StatusOK, body: "198.51.100.1 US"},
+		{name: "through a proxy", remoteAddr: "10.0.0.1:52000", forwarded: "198.51.100.2", status: http.StatusOK, body: "198.51.100.2 US"},
+		{name: "blocked", remoteAddr: "10.0.0.1:52000", forwarded: "203.0.113.1", status: http.StatusForbidden},
+		{name: "redirected", remoteAddr: "192.0.2.1:52000", status: http.StatusFound, location: "https://fr.example.com/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0")
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.body)
+			}
+			if got := rec.Header().Get("Location"); got != tt.location {
+				t.Errorf("Location = %q, want %q", got, tt.location)
+			}
+		})
+	}
+}
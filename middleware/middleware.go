@@ -0,0 +1,245 @@
+// Package middleware geolocates the clients of other Go services in-process.
+// It resolves the client IP, looks it up with an ip2location.Aggregator,
+// parses the device from the User-Agent and client hints, and attaches the
+// result to the request context. Requests can be blocked or redirected by country.
+//
+// Geo holds the configuration; its Handler method adapts it to net/http
+// and its Fiber method to Fiber.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/imnitish-dev/ip2location/ip2location"
+	"github.com/imnitish-dev/ip2location/useragent"
+)
+
+// Config configures a Geo
+type Config struct {
+	// Aggregator locates the client IP with its sources and policy; the
+	// merged location is reported. It is required.
+	Aggregator *ip2location.Aggregator
+	// UserAgents parses Info.Device; devices are not parsed when nil
+	UserAgents *useragent.Parser
+
+	// TrustedProxies are the CIDR blocks whose X-Forwarded-For and
+	// X-Real-IP headers are believed. With none, the peer address is the
+	// client IP.
+	TrustedProxies []string
+
+	// AllowCountries, when set, blocks every other country. DenyCountries
+	// blocks the countries listed. Both take ISO 3166-1 alpha-2 codes.
+	AllowCountries []string
+	DenyCountries  []string
+	// AllowUnknown lets requests from addresses without a country, such as
+	// private ones, past AllowCountries
+	AllowUnknown bool
+	// BlockedURL redirects blocked requests there instead of answering
+	// 403 Forbidden; it must not be served behind the same middleware
+	BlockedURL string
+	// Redirects sends requests from a country, by alpha-2 code, to a URL,
+	// e.g. a regional site. Blocking is checked first.
+	Redirects map[string]string
+}
+
+// Info is what Geo attaches to a request
+type Info struct {
+	// IP is the client IP, canonicalized and with the IPv4 address of a
+	// 6to4, Teredo or NAT64 client extracted
+	IP string
+	// Location is the merged location of the aggregator's sources, nil
+	// when none could locate IP
+	Location *ip2location.Location
+	// Device is nil when Config.UserAgents is
+	Device *useragent.DeviceInfo
+}
+
+// CountryCode returns the alpha-2 code of the client's country, or "" when
+// it is unknown
+func (i *Info) CountryCode() string {
+	if i == nil || i.Location == nil || i.Location.CountryCode == "-" {
+		return ""
+	}
+	return strings.ToUpper(i.Location.CountryCode)
+}
+
+// Geo resolves, geolocates and filters clients; it is safe for concurrent
+// use
+type Geo struct {
+	aggregator *ip2location.Aggregator
+	userAgents *useragent.Parser
+	trusted    []netip.Prefix
+
+	allow        map[string]bool
+	deny         map[string]bool
+	allowUnknown bool
+	blockedURL   string
+	redirects    map[string]string
+}
+
+// New validates config and returns a Geo
+func New(config Config) (*Geo, error) {
+	if config.Aggregator == nil {
+		return nil, fmt.Errorf("no ip2location aggregator configured")
+	}
+
+	g := &Geo{
+		aggregator:   config.Aggregator,
+		userAgents:   config.UserAgents,
+		allow:        countrySet(config.AllowCountries),
+		deny:         countrySet(config.DenyCountries),
+		allowUnknown: config.AllowUnknown,
+		blockedURL:   config.BlockedURL,
+		redirects:    make(map[string]string, len(config.Redirects)),
+	}
+	for _, cidr := range config.TrustedProxies {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		g.trusted = append(g.trusted, prefix.Masked())
+	}
+	for country, url := range config.Redirects {
+		g.redirects[strings.ToUpper(country)] = url
+	}
+	return g, nil
+}
+
+func countrySet(codes []string) map[string]bool {
+	if len(codes) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		set[strings.ToUpper(strings.TrimSpace(code))] = true
+	}
+	return set
+}
+
+// Resolve builds the Info of a request from the peer address, as host:port
+// or a bare IP, and a function returning request headers. The lookup is
+// bounded by ctx.
+func (g *Geo) Resolve(ctx context.Context, remoteAddr string, header func(name string) string) *Info {
+	info := &Info{IP: g.clientIP(remoteAddr, header)}
+	if info.IP != "" {
+		info.Location = g.aggregator.Lookup(ctx, info.IP, ip2location.LookupOptions{}).Merged
+	}
+
+	if g.userAgents != nil {
+		device := g.userAgents.ParseWithHints(header("User-Agent"), useragent.HintsFromHeaders(header))
+		info.Device = &device
+	}
+	return info
+}
+
+// clientIP returns the peer address or, when the peer is a trusted proxy,
+// the last X-Forwarded-For address that is not one, then X-Real-IP. The
+// result is normalized with ip2location.NormalizeAddr, so that 6to4,
+// Teredo and NAT64 clients are located by their IPv4 address.
+func (g *Geo) clientIP(remoteAddr string, header func(name string) string) string {
+	addr, ok := g.clientAddr(remoteAddr, header)
+	if !ok {
+		return ""
+	}
+	return ip2location.NormalizeAddr(addr).String()
+}
+
+// clientAddr finds the client address; proxies are matched as they
+// appear on the wire, before any normalization
+func (g *Geo) clientAddr(remoteAddr string, header func(name string) string) (netip.Addr, bool) {
+	peer, ok := parseIP(remoteAddr)
+	if !ok {
+		return netip.Addr{}, false
+	}
+	if !g.isTrusted(peer) {
+		return peer, true
+	}
+
+	forwarded := strings.Split(header("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr, ok := parseIP(forwarded[i])
+		if !ok {
+			break
+		}
+		if !g.isTrusted(addr) {
+			return addr, true
+		}
+	}
+	if addr, ok := parseIP(header("X-Real-IP")); ok {
+		return addr, true
+	}
+	return peer, true
+}
+
+func (g *Geo) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range g.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIP parses an address with or without a port
+func parseIP(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.WithZone("").Unmap(), true
+}
+
+// Action is what a Geo does with a request
+type Action int
+
+const (
+	// Continue passes the request on
+	Continue Action = iota
+	// Block answers 403 Forbidden
+	Block
+	// Redirect answers 302 Found
+	Redirect
+)
+
+// Decide applies the country rules to info. For Redirect it returns the
+// URL to redirect to.
+func (g *Geo) Decide(info *Info) (Action, string) {
+	country := info.CountryCode()
+
+	blocked := g.deny[country]
+	if g.allow != nil && !g.allow[country] && (country != "" || !g.allowUnknown) {
+		blocked = true
+	}
+	if blocked {
+		if g.blockedURL != "" {
+			return Redirect, g.blockedURL
+		}
+		return Block, ""
+	}
+
+	if url, ok := g.redirects[country]; ok && country != "" {
+		return Redirect, url
+	}
+	return Continue, ""
+}
+
+type infoKey struct{}
+
+// NewContext returns a copy of ctx carrying info
+func NewContext(ctx context.Context, info *Info) context.Context {
+	return context.WithValue(ctx, infoKey{}, info)
+}
+
+// FromContext returns the Info attached by the middleware
+func FromContext(ctx context.Context) (*Info, bool) {
+	info, ok := ctx.Value(infoKey{}).(*Info)
+	return info, ok
+}
@@ -0,0 +1,267 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/imnitish-dev/ip2location/ip2location"
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+)
+
+// countries maps the networks of the test database to their countries
+var countries = map[string]string{
+	"198.51.100.0/24": "US",
+	"203.0.113.0/24":  "GB",
+	"192.0.2.0/24":    "FR",
+}
+
+// newAggregator serves countries from a MaxMind City database
+func newAggregator(t *testing.T) *ip2location.Aggregator {
+	t.Helper()
+	w, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: "GeoLite2-City", RecordSize: 28, IncludeReservedNetworks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for cidr, code := range countries {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record := mmdbtype.Map{"country": mmdbtype.Map{"iso_code": mmdbtype.String(code)}}
+		if err := w.Insert(network, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "city.mmdb")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := w.WriteTo(f); err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := ip2location.NewService(ip2location.MaxMindProvider, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agg, err := ip2location.NewAggregator(ip2location.AggregatorConfig{Policy: ip2location.FirstSuccess}, ip2location.Source{Service: service})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(agg.Close)
+	return agg
+}
+
+func newGeo(t *testing.T, config Config) *Geo {
+	t.Helper()
+	config.Aggregator = newAggregator(t)
+	g, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// headers serves a fixed set of request headers
+func headers(h map[string]string) func(string) string {
+	return func(name string) string { return h[name] }
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{}); err == nil {
+		t.Error("New() accepted a config without an aggregator")
+	}
+	if _, err := New(Config{Aggregator: newAggregator(t), TrustedProxies: []string{"10.0.0.0/33"}}); err == nil {
+		t.Error("New() accepted an invalid trusted proxy")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	g := newGeo(t, Config{TrustedProxies: []string{"10.0.0.0/8", " fd00::/8"}})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     map[string]string
+		want       string
+	}{
+		{
+			name:       "untrusted peer",
+			remoteAddr: "198.51.100.1:52000",
+			header:     map[string]string{"X-Forwarded-For": "203.0.113.1", "X-Real-IP": "203.0.113.2"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "bare peer address",
+			remoteAddr: "198.51.100.1",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted peer",
+			remoteAddr: "10.0.0.1:52000",
+			header:     map[string]string{"X-Forwarded-For": "203.0.113.1"},
+			want:       "203.0.113.1",
+		},
+		{
+			// The client can prepend anything; only the hops added by
+			// trusted proxies are believed
+			name:       "multiple hops",
+			remoteAddr: "10.0.0.1:52000",
+			header:     map[string]string{"X-Forwarded-For": "192.0.2.1, 203.0.113.1, 10.0.0.2"},
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "unparsable hop",
+			remoteAddr: "10.0.0.1:52000",
+			header:     map[string]string{"X-Forwarded-For": "203.0.113.1, unknown, 10.0.0.2"},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "X-Real-IP",
+			remoteAddr: "10.0.0.1:52000",
+			header:     map[string]string{"X-Forwarded-For": "10.0.0.2", "X-Real-IP": "203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted peer without headers",
+			remoteAddr: "10.0.0.1:52000",
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "trusted IPv6 peer",
+			remoteAddr: "[fd00::1]:443",
+			header:     map[string]string{"X-Forwarded-For": "2001:db8::1"},
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "IPv4-mapped",
+			remoteAddr: "[::ffff:198.51.100.1]:52000",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "6to4",
+			remoteAddr: "[2002:c633:6401::1]:52000",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "Teredo behind a proxy",
+			remoteAddr: "10.0.0.1:52000",
+			header:     map[string]string{"X-Forwarded-For": "2001:0:4136:e378:8000:63bf:39cc:9bfe"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "invalid peer",
+			remoteAddr: "pipe",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.clientIP(tt.remoteAddr, headers(tt.header)); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	g := newGeo(t, Config{})
+
+	tests := []struct {
+		remoteAddr, ip, country string
+	}{
+		{"203.0.113.9:443", "203.0.113.9", "GB"},
+		// Located by the embedded IPv4 address
+		{"[2002:c633:6401::1]:443", "198.51.100.1", "US"},
+		{"10.0.0.1:443", "10.0.0.1", ""},
+		{"pipe", "", ""},
+	}
+	for _, tt := range tests {
+		info := g.Resolve(context.Background(), tt.remoteAddr, headers(nil))
+		if info.IP != tt.ip || info.CountryCode() != tt.country {
+			t.Errorf("Resolve(%s) = %s in %q, want %s in %q", tt.remoteAddr, info.IP, info.CountryCode(), tt.ip, tt.country)
+		}
+		if info.Device != nil {
+			t.Errorf("Resolve(%s) parsed a device without UserAgents", tt.remoteAddr)
+		}
+	}
+}
+
+func TestDecide(t *testing.T) {
+	agg := newAggregator(t)
+	in := func(code string) *Info {
+		if code == "" {
+			return &Info{}
+		}
+		return &Info{Location: &ip2location.Location{CountryCode: code}}
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+		info   *Info
+		action Action
+		url    string
+	}{
+		{name: "no rules", info: in("KP"), action: Continue},
+		{name: "denied", config: Config{DenyCountries: []string{"kp"}}, info: in("KP"), action: Block},
+		{name: "not denied", config: Config{DenyCountries: []string{"KP"}}, info: in("US"), action: Continue},
+		{name: "allowed", config: Config{AllowCountries: []string{"US", " gb"}}, info: in("gb"), action: Continue},
+		{name: "not allowed", config: Config{AllowCountries: []string{"US"}}, info: in("FR"), action: Block},
+		{name: "unknown not allowed", config: Config{AllowCountries: []string{"US"}}, info: in(""), action: Block},
+		{
+			// IP2Location reports "-" for addresses it has no country for
+			name: "unknown allowed", config: Config{AllowCountries: []string{"US"}, AllowUnknown: true},
+			info: in("-"), action: Continue,
+		},
+		{
+			name: "denied beats allowed", config: Config{AllowCountries: []string{"KP"}, DenyCountries: []string{"KP"}},
+			info: in("KP"), action: Block,
+		},
+		{
+			name: "blocked URL", config: Config{DenyCountries: []string{"KP"}, BlockedURL: "https://example.com/blocked"},
+			info: in("KP"), action: Redirect, url: "https://example.com/blocked",
+		},
+		{
+			name: "redirect", config: Config{Redirects: map[string]string{"fr": "https://fr.example.com"}},
+			info: in("FR"), action: Redirect, url: "https://fr.example.com",
+		},
+		{
+			name: "no redirect", config: Config{Redirects: map[string]string{"FR": "https://fr.example.com"}},
+			info: in("US"), action: Continue,
+		},
+		{
+			// Blocking is checked before redirects
+			name: "block before redirect",
+			config: Config{
+				DenyCountries: []string{"FR"},
+				BlockedURL:    "https://example.com/blocked",
+				Redirects:     map[string]string{"FR": "https://fr.example.com"},
+			},
+			info: in("FR"), action: Redirect, url: "https://example.com/blocked",
+		},
+		{
+			name: "redirect of an unknown country", config: Config{Redirects: map[string]string{"": "https://example.com/where"}},
+			info: in(""), action: Continue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Aggregator = agg
+			g, err := New(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if action, url := g.Decide(tt.info); action != tt.action || url != tt.url {
+				t.Errorf("Decide() = %v %q, want %v %q", action, url, tt.action, tt.url)
+			}
+		})
+	}
+}
@@ -0,0 +1,651 @@
+package main
+
+import (
+	_ "embed"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// docVersion is reported in the OpenAPI document's info object
+const docVersion = "2.0.0"
+
+// operation documents one HTTP route. Body and Result are sample values
+// whose types become the request and 200 response schemas; a slice of
+// samples documents a body that may take several shapes.
+type operation struct {
+	Method  string
+	Path    string
+	Summary string
+	Params  []parameter
+	Body    []interface{}
+	Result  []interface{}
+
+	// Negotiated responses are available in every output format and take
+	// the format and fields query parameters
+	Negotiated bool
+	// Produces is the media type of routes that are not negotiated;
+	// JSON when empty
+	Produces string
+
+	// RPC is the gRPC method behind a Connect procedure or a transcoded
+	// route; its messages are documented in their JSON form
+	RPC     protoreflect.MethodDescriptor
+	Connect bool
+	Binding *httpBinding
+
+	// Deprecated marks the unversioned aliases of /v1 routes
+	Deprecated bool
+	// Error is a sample of the error body; Response when nil
+	Error interface{}
+	// NotFound documents a 404 for path parameters that name nothing
+	NotFound bool
+	// Bounded routes take X-Request-Timeout and may answer 504;
+	// negotiated routes always do
+	Bounded bool
+}
+
+// parameter documents a path, query or header parameter
+type parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      schema `json:"schema"`
+}
+
+type schema map[string]interface{}
+
+var (
+	formatParam = parameter{
+		Name:        "format",
+		In:          "query",
+		Description: "Output format; overrides the Accept header",
+		Schema:      schema{"type": "string", "enum": []string{FormatJSON, FormatXML, FormatCSV, FormatMsgPack, FormatProtobuf, FormatGeoJSON}},
+	}
+	fieldsParam = parameter{
+		Name:        "fields",
+		In:          "query",
+		Description: "Comma-separated field paths to return, e.g. maxmind.country_code,deviceBrowser.os",
+		Schema:      schema{"type": "string"},
+	}
+	timeoutParam = parameter{
+		Name:        requestTimeoutHeader,
+		In:          "header",
+		Description: "How long the caller will wait, e.g. 850ms; the request fails with 504 once it has passed",
+		Schema:      schema{"type": "string"},
+	}
+	hintParams = func() []parameter {
+		params := []parameter{{
+			Name:   fiber.HeaderUserAgent,
+			In:     "header",
+			Schema: schema{"type": "string"},
+		}}
+		for _, name := range []string{"Sec-CH-UA", "Sec-CH-UA-Mobile", "Sec-CH-UA-Platform", "Sec-CH-UA-Platform-Version", "Sec-CH-UA-Full-Version-List", "Sec-CH-UA-Model", "Sec-CH-UA-Arch"} {
+			params = append(params, parameter{
+				Name:        name,
+				In:          "header",
+				Description: "User-Agent Client Hint",
+				Schema:      schema{"type": "string"},
+			})
+		}
+		return params
+	}()
+)
+
+// apiOperations documents the routes of apiRoutes under prefix, given
+// samples of the lookup response, the user-agent result and the user-agent
+// query of an API version
+func apiOperations(prefix string, response, result, query interface{}, deprecated bool) []operation {
+	list := func(sample interface{}) interface{} {
+		return reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(sample)), 0, 0).Interface()
+	}
+
+	ops := []operation{
+		{
+			Method:     fiber.MethodGet,
+			Path:       prefix + "/",
+			Summary:    "Geolocate the caller's own address",
+			Params:     hintParams,
+			Result:     []interface{}{response},
+			Negotiated: true,
+		},
+		{
+			Method:  fiber.MethodGet,
+			Path:    prefix + "/lookup/:ip",
+			Summary: "Geolocate an IP address or host name",
+			Params: append([]parameter{{
+				Name:        "ip",
+				In:          "path",
+				Description: "IPv4 or IPv6 address, or a host name to resolve",
+				Required:    true,
+				Schema:      schema{"type": "string"},
+			}}, hintParams...),
+			Result:     []interface{}{response},
+			Negotiated: true,
+		},
+		{
+			Method:     fiber.MethodPost,
+			Path:       prefix + "/lookup",
+			Summary:    "Geolocate up to " + strconv.Itoa(maxLookupBatch) + " addresses or host names",
+			Params:     hintParams,
+			Body:       []interface{}{[]string{}},
+			Result:     []interface{}{list(response)},
+			Negotiated: true,
+		},
+		{
+			Method:  fiber.MethodGet,
+			Path:    prefix + "/ua",
+			Summary: "Parse a user agent, or the caller's own",
+			Params: append([]parameter{{
+				Name:        "ua",
+				In:          "query",
+				Description: "User-Agent string; defaults to the request's User-Agent header",
+				Schema:      schema{"type": "string"},
+			}}, hintParams...),
+			Result: []interface{}{result},
+		},
+		{
+			Method:  fiber.MethodPost,
+			Path:    prefix + "/ua",
+			Summary: "Parse one user agent or up to " + strconv.Itoa(maxUABatch),
+			Params:  hintParams,
+			Body:    []interface{}{query, list(query)},
+			Result:  []interface{}{result, list(result)},
+		},
+	}
+	for i := range ops {
+		ops[i].Deprecated = deprecated
+		ops[i].Error = response
+	}
+	return ops
+}
+
+// operations documents every route registered in setupRoutes; the RPC
+// routes are documented by setupRPCRoutes. NewApp fails when a route is
+// missing.
+var operations = append(append(append(
+	apiOperations("/v1", Response{}, UAResult{}, UAQuery{}, false),
+	apiOperations("/v2", ResponseV2{}, UAResultV2{}, UAQueryV2{}, false)...),
+	apiOperations("", Response{}, UAResult{}, UAQuery{}, true)...),
+	[]operation{
+		{
+			Method:  fiber.MethodGet,
+			Path:    "/v2/countries/:code",
+			Summary: "Metadata of a country",
+			Params: []parameter{{
+				Name:        "code",
+				In:          "path",
+				Description: "ISO 3166-1 alpha-2, alpha-3 or numeric code",
+				Required:    true,
+				Schema:      schema{"type": "string"},
+			}},
+			Result:   []interface{}{CountryV2{}},
+			Error:    ResponseV2{},
+			NotFound: true,
+		},
+		{
+			Method:  fiber.MethodPost,
+			Path:    "/v2/decide",
+			Summary: "Allow, deny or flag an address and device for review with the geofencing rules",
+			Params:  hintParams,
+			Body:    []interface{}{DecideRequestV2{}},
+			Result:  []interface{}{DecisionV2{}},
+			Error:   ResponseV2{},
+			Bounded: true,
+		},
+		{
+			Method:  fiber.MethodPost,
+			Path:    "/decide",
+			Summary: "Same as POST /v2/decide, which has no /v1 counterpart to alias",
+			Params:  hintParams,
+			Body:    []interface{}{DecideRequestV2{}},
+			Result:  []interface{}{DecisionV2{}},
+			Error:   ResponseV2{},
+			Bounded: true,
+		},
+		{
+			Method:     fiber.MethodGet,
+			Path:       "/health",
+			Summary:    "Health check",
+			Result:     []interface{}{Response{}},
+			Negotiated: true,
+		},
+		{
+			Method:  fiber.MethodGet,
+			Path:    "/openapi.json",
+			Summary: "This OpenAPI document",
+			Result:  []interface{}{map[string]interface{}{}},
+		},
+		{
+			Method:   fiber.MethodGet,
+			Path:     "/docs",
+			Summary:  "Interactive API documentation",
+			Produces: fiber.MIMETextHTMLCharsetUTF8,
+		},
+		{
+			Method:   fiber.MethodGet,
+			Path:     "/docs/redoc.standalone.js",
+			Summary:  "The Redoc bundle the documentation page loads",
+			Produces: fiber.MIMEApplicationJavaScriptCharsetUTF8,
+		},
+	}...)
+
+// openAPIPath converts a Fiber route path to an OpenAPI path template
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + strings.TrimSuffix(segment[1:], "?") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// schemaBuilder derives JSON Schemas from Go types, collecting named
+// structs under components
+type schemaBuilder struct {
+	components map[string]schema
+}
+
+// of returns the schema of t, using the same JSON names as the encoders
+func (b *schemaBuilder) of(t reflect.Type) schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		elem := b.of(t.Elem())
+		if typ, ok := elem["type"].(string); ok {
+			return schema{"type": []string{typ, "null"}}
+		}
+		return elem
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := b.components[name]; !ok {
+			b.components[name] = nil // guards against recursive types
+			b.components[name] = b.object(t)
+		}
+		return schema{"$ref": "#/components/schemas/" + name}
+	case reflect.Slice, reflect.Array:
+		return schema{"type": "array", "items": b.of(t.Elem())}
+	case reflect.Map:
+		return schema{"type": "object", "additionalProperties": b.of(t.Elem())}
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return schema{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	default:
+		return schema{}
+	}
+}
+
+// object builds the schema of a struct type. Fields without omitempty are
+// always present and so required.
+func (b *schemaBuilder) object(t reflect.Type) schema {
+	properties := make(map[string]schema)
+	required := []string{}
+	for _, field := range jsonFields(t) {
+		properties[field.name] = b.of(t.FieldByIndex(field.index).Type)
+		if !field.omitEmpty {
+			required = append(required, field.name)
+		}
+	}
+
+	s := schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// ofSamples returns the schema of one sample, or a oneOf over several
+func (b *schemaBuilder) ofSamples(samples []interface{}) schema {
+	if len(samples) == 1 {
+		return b.of(reflect.TypeOf(samples[0]))
+	}
+	var oneOf []schema
+	for _, sample := range samples {
+		oneOf = append(oneOf, b.of(reflect.TypeOf(sample)))
+	}
+	return schema{"oneOf": oneOf}
+}
+
+// buildOpenAPI generates the OpenAPI 3.1 document for operations
+func buildOpenAPI(operations []operation) ([]byte, error) {
+	b := &schemaBuilder{components: make(map[string]schema)}
+	errorSchema := b.of(reflect.TypeOf(Response{}))
+
+	paths := make(map[string]map[string]interface{})
+	for _, op := range operations {
+		path := openAPIPath(op.Path)
+		if paths[path] == nil {
+			paths[path] = make(map[string]interface{})
+		}
+		if op.RPC != nil {
+			paths[path][strings.ToLower(op.Method)] = b.rpcOperation(op)
+			continue
+		}
+
+		params := op.Params
+		if op.Negotiated {
+			params = append([]parameter{formatParam, fieldsParam}, params...)
+		}
+		bounded := op.Negotiated || op.Bounded
+		if bounded {
+			params = append(params[:len(params):len(params)], timeoutParam)
+		}
+
+		var content map[string]interface{}
+		if op.Negotiated {
+			content = negotiatedContent(b, b.ofSamples(op.Result))
+		} else if op.Produces != "" {
+			content = map[string]interface{}{op.Produces: map[string]interface{}{"schema": schema{"type": "string"}}}
+		} else {
+			content = map[string]interface{}{fiber.MIMEApplicationJSON: map[string]interface{}{"schema": b.ofSamples(op.Result)}}
+		}
+
+		responses := map[string]interface{}{
+			"200": map[string]interface{}{"description": "OK", "content": content},
+		}
+		errorSchema := errorSchema
+		if op.Error != nil {
+			errorSchema = b.of(reflect.TypeOf(op.Error))
+		}
+		if len(op.Params) > 0 || len(op.Body) > 0 || op.Negotiated {
+			responses["400"] = errorResponse("Invalid request", errorSchema)
+		}
+		if op.Negotiated {
+			responses["406"] = errorResponse("Unsupported format", errorSchema)
+		}
+		if op.NotFound {
+			responses["404"] = errorResponse("Not found", errorSchema)
+		}
+		if bounded {
+			responses["504"] = errorResponse("X-Request-Timeout passed", errorSchema)
+		}
+
+		doc := map[string]interface{}{
+			"summary":     op.Summary,
+			"operationId": operationID(op),
+			"responses":   responses,
+		}
+		if op.Deprecated {
+			doc["deprecated"] = true
+		}
+		if len(params) > 0 {
+			doc["parameters"] = params
+		}
+		if len(op.Body) > 0 {
+			doc["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					fiber.MIMEApplicationJSON: map[string]interface{}{"schema": b.ofSamples(op.Body)},
+				},
+			}
+		}
+		paths[path][strings.ToLower(op.Method)] = doc
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":       "IP Geolocation API",
+			"version":     docVersion,
+			"description": "Geolocates IP addresses and host names with MaxMind and IP2Location, and parses user agents.",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": b.components},
+	})
+}
+
+// negotiatedContent lists every output format for a response schema.
+// GeoJSON has its own schema; CSV and protobuf are opaque.
+func negotiatedContent(b *schemaBuilder, s schema) map[string]interface{} {
+	content := make(map[string]interface{})
+	for format, types := range formatTypes {
+		var formatSchema schema
+		switch format {
+		case FormatJSON, FormatXML, FormatMsgPack:
+			formatSchema = s
+		case FormatGeoJSON:
+			formatSchema = b.of(reflect.TypeOf(FeatureCollection{}))
+		default:
+			formatSchema = schema{"type": "string", "format": "binary"}
+		}
+		content[types[0]] = map[string]interface{}{"schema": formatSchema}
+	}
+	return content
+}
+
+// rpcOperation documents a Connect procedure or a transcoded route of a
+// gRPC method
+func (b *schemaBuilder) rpcOperation(op operation) map[string]interface{} {
+	input := b.ofMessage(op.RPC.Input())
+	output := b.ofMessage(op.RPC.Output())
+	doc := map[string]interface{}{
+		"summary":     op.Summary,
+		"operationId": operationID(op),
+	}
+	if op.Deprecated {
+		doc["deprecated"] = true
+	}
+
+	if op.Connect {
+		binary := map[string]interface{}{"schema": schema{"type": "string", "format": "binary"}}
+		doc["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				fiber.MIMEApplicationJSON:    map[string]interface{}{"schema": input},
+				"application/proto":          binary,
+				"application/grpc-web+proto": binary,
+			},
+		}
+		doc["responses"] = map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "OK",
+				"content": map[string]interface{}{
+					fiber.MIMEApplicationJSON:    map[string]interface{}{"schema": output},
+					"application/proto":          binary,
+					"application/grpc-web+proto": binary,
+				},
+			},
+		}
+		return doc
+	}
+
+	fields := op.RPC.Input().Fields()
+	bound := make(map[string]bool)
+	var params []parameter
+	for _, name := range op.Binding.params {
+		bound[name] = true
+		params = append(params, parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   b.ofField(fields.ByName(protoreflect.Name(name))),
+		})
+	}
+
+	switch op.Binding.body {
+	case "*":
+		doc["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				fiber.MIMEApplicationJSON: map[string]interface{}{"schema": input},
+			},
+		}
+	default:
+		if op.Binding.body != "" {
+			field := fields.ByName(protoreflect.Name(op.Binding.body))
+			bound[op.Binding.body] = true
+			doc["requestBody"] = map[string]interface{}{
+				"content": map[string]interface{}{
+					fiber.MIMEApplicationJSON: map[string]interface{}{"schema": b.ofField(field)},
+				},
+			}
+		}
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			if bound[string(field.Name())] || field.IsMap() {
+				continue
+			}
+			params = append(params, parameter{
+				Name:   string(field.Name()),
+				In:     "query",
+				Schema: b.ofField(field),
+			})
+		}
+	}
+	params = append(params, timeoutParam)
+	doc["parameters"] = params
+
+	doc["responses"] = map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "OK",
+			"content": map[string]interface{}{
+				fiber.MIMEApplicationJSON: map[string]interface{}{"schema": output},
+			},
+		},
+		"default": errorResponse("Error", statusSchema),
+	}
+	return doc
+}
+
+// statusSchema is the google.rpc.Status JSON object of transcoded errors
+var statusSchema = schema{
+	"type": "object",
+	"properties": map[string]schema{
+		"code":    {"type": "integer", "format": "int32"},
+		"message": {"type": "string"},
+		"details": {"type": "array", "items": schema{"type": "object"}},
+	},
+}
+
+// ofMessage returns the schema of the protobuf JSON form of a message
+func (b *schemaBuilder) ofMessage(md protoreflect.MessageDescriptor) schema {
+	name := string(md.FullName())
+	if _, ok := b.components[name]; !ok {
+		b.components[name] = nil // guards against recursive types
+		properties := make(map[string]schema)
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			properties[field.JSONName()] = b.ofField(field)
+		}
+		b.components[name] = schema{"type": "object", "properties": properties}
+	}
+	return schema{"$ref": "#/components/schemas/" + name}
+}
+
+// ofField returns the schema of a message field in protobuf JSON
+func (b *schemaBuilder) ofField(field protoreflect.FieldDescriptor) schema {
+	switch {
+	case field.IsMap():
+		return schema{"type": "object", "additionalProperties": b.ofKind(field.MapValue())}
+	case field.IsList():
+		return schema{"type": "array", "items": b.ofKind(field)}
+	default:
+		return b.ofKind(field)
+	}
+}
+
+// ofKind returns the schema of a single value of field; 64-bit integers
+// are strings in protobuf JSON
+func (b *schemaBuilder) ofKind(field protoreflect.FieldDescriptor) schema {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return schema{"type": "string"}
+	case protoreflect.BoolKind:
+		return schema{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return schema{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return schema{"type": "integer", "format": "int32", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return schema{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return schema{"type": "number"}
+	case protoreflect.BytesKind:
+		return schema{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		var names []string
+		values := field.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return schema{"type": "string", "enum": names}
+	default:
+		if field.Message().FullName() == "google.protobuf.FieldMask" {
+			return schema{"type": "string", "description": "Comma-separated field paths"}
+		}
+		return b.ofMessage(field.Message())
+	}
+}
+
+func errorResponse(description string, s schema) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			fiber.MIMEApplicationJSON: map[string]interface{}{"schema": s},
+		},
+	}
+}
+
+// operationID names an operation after its method and path, e.g.
+// getLookupIp
+func operationID(op operation) string {
+	id := strings.ToLower(op.Method)
+	for _, segment := range strings.FieldsFunc(op.Path, func(r rune) bool {
+		return r == '/' || r == ':' || r == '.' || r == '?'
+	}) {
+		id += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	if op.Path == "/" {
+		id += "Root"
+	}
+	return id
+}
+
+// redocBundle is served by the binary so that /docs works offline
+//
+//go:embed assets/redoc.standalone.js
+var redocBundle []byte
+
+// docsPage renders the OpenAPI document with Redoc
+const docsPage = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>IP Geolocation API</title>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+  </head>
+  <body>
+    <redoc spec-url="/openapi.json"></redoc>
+    <script src="/docs/redoc.standalone.js"></script>
+  </body>
+</html>
+`
+
+func (a *App) handleOpenAPI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(a.openAPI)
+}
+
+func handleDocs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(docsPage)
+}
+
+func handleRedoc(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJavaScriptCharsetUTF8)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	return c.Send(redocBundle)
+}
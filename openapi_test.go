@@ -0,0 +1,88 @@
+package main
+
+import (
+	"io"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/useragent"
+)
+
+// newRoutesApp registers every route without opening any database, which
+// is enough to inspect the routing table and the documentation
+func newRoutesApp(t *testing.T) (*App, []operation) {
+	t.Helper()
+	app := &App{
+		fiber: fiber.New(fiber.Config{ErrorHandler: errorHandler, JSONEncoder: json.Marshal, JSONDecoder: json.Unmarshal}),
+		ua:    useragent.NewDefault(100),
+	}
+	app.setupRoutes()
+	rpcOperations, err := app.setupRPCRoutes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	documented := append(append([]operation{}, operations...), rpcOperations...)
+	if app.openAPI, err = buildOpenAPI(documented); err != nil {
+		t.Fatal(err)
+	}
+	return app, documented
+}
+
+// TestRoutesDocumented fails for every registered route without an entry
+// in operations. HEAD routes mirror GET and OPTIONS routes answer CORS
+// preflights, so both are skipped.
+func TestRoutesDocumented(t *testing.T) {
+	app, documented := newRoutesApp(t)
+
+	entries := make(map[string]bool)
+	for _, op := range documented {
+		entries[op.Method+" "+op.Path] = true
+	}
+
+	var missing []string
+	for _, route := range app.fiber.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || route.Method == fiber.MethodOptions {
+			continue
+		}
+		if key := route.Method + " " + route.Path; !entries[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		t.Errorf("route %s is missing from the OpenAPI document", key)
+	}
+}
+
+func TestDocsServedOffline(t *testing.T) {
+	app, _ := newRoutesApp(t)
+
+	res, err := app.fiber.Test(httptest.NewRequest("GET", "/docs", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := io.ReadAll(res.Body)
+	if strings.Contains(string(page), "https://") {
+		t.Errorf("/docs loads remote resources:\n%s", page)
+	}
+	if !strings.Contains(string(page), `src="/docs/redoc.standalone.js"`) {
+		t.Errorf("/docs does not load the embedded bundle:\n%s", page)
+	}
+
+	res, err = app.fiber.Test(httptest.NewRequest("GET", "/docs/redoc.standalone.js", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, _ := io.ReadAll(res.Body)
+	if res.StatusCode != fiber.StatusOK || !strings.HasPrefix(res.Header.Get(fiber.HeaderContentType), fiber.MIMEApplicationJavaScript) {
+		t.Errorf("bundle served with %d %s", res.StatusCode, res.Header.Get(fiber.HeaderContentType))
+	}
+	if len(bundle) != len(redocBundle) || len(bundle) == 0 {
+		t.Errorf("bundle is %d bytes, want %d", len(bundle), len(redocBundle))
+	}
+}
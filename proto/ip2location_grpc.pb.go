@@ -19,14 +19,21 @@ import (
 
 
 const (
-	IP2LocationService_LookupIP_FullMethodName = "/ip2location.IP2LocationService/LookupIP"
+	IP2LocationService_LookupIP_FullMethodName       = "/ip2location.IP2LocationService/LookupIP"
+	IP2LocationService_ParseUserAgent_FullMethodName = "/ip2location.IP2LocationService/ParseUserAgent"
 )
 
 // IP2LocationServiceClient is the client API for IP2LocationService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Version 1 of the API, frozen in the shape of the original REST responses;
+// new fields go to ip2location.v2. Besides native gRPC, the service is
+// reachable on the HTTP port over the Connect protocol, gRPC-Web, and the
+// JSON routes given by the google.api.http annotations.
 type IP2LocationServiceClient interface {
 	LookupIP(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error)
+	ParseUserAgent(ctx context.Context, in *ParseUserAgentRequest, opts ...grpc.CallOption) (*ParseUserAgentResponse, error)
 }
 
 type iP2LocationServiceClient struct {
@@ -47,11 +54,27 @@ func (c *iP2LocationServiceClient) LookupIP(ctx context.Context, in *LookupReque
 	return out, nil
 }
 
+func (c *iP2LocationServiceClient) ParseUserAgent(ctx context.Context, in *ParseUserAgentRequest, opts ...grpc.CallOption) (*ParseUserAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ParseUserAgentResponse)
+	err := c.cc.Invoke(ctx, IP2LocationService_ParseUserAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IP2LocationServiceServer is the server API for IP2LocationService service.
 // All implementations must embed UnimplementedIP2LocationServiceServer
 // for forward compatibility.
+//
+// Version 1 of the API, frozen in the shape of the original REST responses;
+// new fields go to ip2location.v2. Besides native gRPC, the service is
+// reachable on the HTTP port over the Connect protocol, gRPC-Web, and the
+// JSON routes given by the google.api.http annotations.
 type IP2LocationServiceServer interface {
 	LookupIP(context.Context, *LookupRequest) (*LookupResponse, error)
+	ParseUserAgent(context.Context, *ParseUserAgentRequest) (*ParseUserAgentResponse, error)
 	mustEmbedUnimplementedIP2LocationServiceServer()
 }
 
@@ -65,6 +88,9 @@ type UnimplementedIP2LocationServiceServer struct{}
 func (UnimplementedIP2LocationServiceServer) LookupIP(context.Context, *LookupRequest) (*LookupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LookupIP not implemented")
 }
+func (UnimplementedIP2LocationServiceServer) ParseUserAgent(context.Context, *ParseUserAgentRequest) (*ParseUserAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseUserAgent not implemented")
+}
 func (UnimplementedIP2LocationServiceServer) mustEmbedUnimplementedIP2LocationServiceServer() {}
 func (UnimplementedIP2LocationServiceServer) testEmbeddedByValue()                            {}
 
@@ -104,6 +130,24 @@ func _IP2LocationService_LookupIP_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IP2LocationService_ParseUserAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseUserAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IP2LocationServiceServer).ParseUserAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IP2LocationService_ParseUserAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IP2LocationServiceServer).ParseUserAgent(ctx, req.(*ParseUserAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IP2LocationService_ServiceDesc is the grpc.ServiceDesc for IP2LocationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +159,10 @@ var IP2LocationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LookupIP",
 			Handler:    _IP2LocationService_LookupIP_Handler,
 		},
+		{
+			MethodName: "ParseUserAgent",
+			Handler:    _IP2LocationService_ParseUserAgent_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/ip2location.proto",
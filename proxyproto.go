@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/pires/go-proxyproto"
+	"google.golang.org/grpc/peer"
+)
+
+// proxyHeaderTimeout bounds how long a new connection may take to send its
+// PROXY header before it is treated as a plain connection. Tests shorten it.
+var proxyHeaderTimeout = 5 * time.Second
+
+// listen opens a TCP listener on address. When PROXY protocol support is
+// enabled the listener reads v1/v2 headers from the configured load balancer
+// CIDRs, so RemoteAddr reports the original client. Headers sent from any
+// other source are rejected rather than trusted.
+func listen(address string, config *Config) (net.Listener, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.ProxyProtocol {
+		return ln, nil
+	}
+
+	policy, err := proxyproto.StrictWhiteListPolicy(config.ProxyProtocolCIDRs)
+	if err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("invalid PROXY protocol CIDR: %w", err)
+	}
+
+	log.Printf("PROXY protocol enabled on %s for %v", address, config.ProxyProtocolCIDRs)
+	return &proxyproto.Listener{
+		Listener:          ln,
+		Policy:            policy,
+		ReadHeaderTimeout: proxyHeaderTimeout,
+	}, nil
+}
+
+// peerIP returns the IP of the gRPC caller. Behind a PROXY protocol listener
+// this is the client address announced by the load balancer.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
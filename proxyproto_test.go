@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pires/go-proxyproto"
+	"google.golang.org/grpc/peer"
+)
+
+// dialProxy connects to ln, sends data and returns the accepted end
+func dialProxy(t *testing.T, ln net.Listener, data string) net.Conn {
+	t.Helper()
+	client, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	if _, err := io.WriteString(client, data); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func listenProxy(t *testing.T, cidrs ...string) net.Listener {
+	t.Helper()
+	ln, err := listen("127.0.0.1:0", &Config{ProxyProtocol: true, ProxyProtocolCIDRs: cidrs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return ln
+}
+
+const proxyHeader = "PROXY TCP4 203.0.113.7 127.0.0.1 51234 443\r\n"
+
+func TestListenProxyProtocol(t *testing.T) {
+	t.Run("allow-listed upstream", func(t *testing.T) {
+		conn := dialProxy(t, listenProxy(t, "127.0.0.0/8"), proxyHeader+"ping")
+		if got := conn.RemoteAddr().String(); got != "203.0.113.7:51234" {
+			t.Errorf("RemoteAddr() = %s, want the client in the header", got)
+		}
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != "ping" {
+			t.Errorf("Read() = %q, %v", buf, err)
+		}
+	})
+
+	t.Run("allow-listed upstream without a header", func(t *testing.T) {
+		conn := dialProxy(t, listenProxy(t, "127.0.0.0/8"), "ping")
+		if host, _, _ := net.SplitHostPort(conn.RemoteAddr().String()); host != "127.0.0.1" {
+			t.Errorf("RemoteAddr() = %s, want the peer", conn.RemoteAddr())
+		}
+	})
+
+	t.Run("other peer", func(t *testing.T) {
+		conn := dialProxy(t, listenProxy(t, "192.0.2.0/24"), proxyHeader+"ping")
+		// The header is not trusted and the connection fails
+		if _, err := conn.Read(make([]byte, 4)); !errors.Is(err, proxyproto.ErrSuperfluousProxyHeader) {
+			t.Errorf("Read() = %v, want %v", err, proxyproto.ErrSuperfluousProxyHeader)
+		}
+		if host, _, _ := net.SplitHostPort(conn.RemoteAddr().String()); host != "127.0.0.1" {
+			t.Errorf("RemoteAddr() = %s, want the peer", conn.RemoteAddr())
+		}
+	})
+
+	t.Run("header timeout", func(t *testing.T) {
+		timeout := proxyHeaderTimeout
+		proxyHeaderTimeout = 50 * time.Millisecond
+		t.Cleanup(func() { proxyHeaderTimeout = timeout })
+
+		// A peer that sends nothing is treated as a plain connection once
+		// the timeout passes, rather than holding up the server
+		conn := dialProxy(t, listenProxy(t, "127.0.0.0/8"), "")
+		start := time.Now()
+		addr := conn.RemoteAddr()
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > 2*time.Second {
+			t.Errorf("RemoteAddr() returned after %v, want the header timeout", elapsed)
+		}
+		if host, _, _ := net.SplitHostPort(addr.String()); host != "127.0.0.1" {
+			t.Errorf("RemoteAddr() = %s, want the peer", addr)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ln, err := listen("127.0.0.1:0", &Config{ProxyProtocolCIDRs: []string{"127.0.0.0/8"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ln.Close()
+		if _, ok := ln.(*proxyproto.Listener); ok {
+			t.Error("listen() read PROXY headers when disabled")
+		}
+	})
+
+	t.Run("invalid CIDR", func(t *testing.T) {
+		if ln, err := listen("127.0.0.1:0", &Config{ProxyProtocol: true, ProxyProtocolCIDRs: []string{"nope"}}); err == nil {
+			ln.Close()
+			t.Error("listen() accepted an invalid CIDR")
+		}
+	})
+}
+
+func TestPeerIP(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no peer", context.Background(), ""},
+		{"tcp", peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 51234}}), "203.0.113.7"},
+		{"ipv6", peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 443}}), "2001:db8::1"},
+		{"unix", peer.NewContext(context.Background(), &peer.Peer{Addr: &net.UnixAddr{Name: "/run/ip2location.sock", Net: "unix"}}), "/run/ip2location.sock"},
+	}
+	for _, tt := range tests {
+		if got := peerIP(tt.ctx); got != tt.want {
+			t.Errorf("%s: peerIP() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Public IP discovery modes
+const (
+	PublicIPModeOff    = "off"
+	PublicIPModeStatic = "static"
+	PublicIPModeHTTP   = "http"
+)
+
+// defaultPublicIPEndpoints are plain-text echo services tried in order
+var defaultPublicIPEndpoints = []string{
+	"https://api.ipify.org",
+	"https://checkip.amazonaws.com",
+	"https://icanhazip.com",
+}
+
+// ErrPublicIPDisabled is returned when egress IP discovery is turned off
+var ErrPublicIPDisabled = errors.New("public IP discovery is disabled")
+
+// PublicIPResolver discovers the address this service egresses from. It is
+// used when a request arrives from a local address, e.g. during development.
+type PublicIPResolver interface {
+	PublicIP(ctx context.Context) (string, error)
+}
+
+// newPublicIPResolver builds the resolver selected by the configuration
+func newPublicIPResolver(config *Config) (PublicIPResolver, error) {
+	var resolver PublicIPResolver
+
+	switch config.PublicIPMode {
+	case PublicIPModeOff:
+		return disabledResolver{}, nil
+	case PublicIPModeStatic:
+		ip := net.ParseIP(config.PublicIP)
+		if ip == nil {
+			return nil, fmt.Errorf("PUBLIC_IP %q is not a valid IP address", config.PublicIP)
+		}
+		return staticResolver(ip.String()), nil
+	case PublicIPModeHTTP:
+		endpoints := config.PublicIPEndpoints
+		if len(endpoints) == 0 {
+			endpoints = defaultPublicIPEndpoints
+		}
+		resolver = &httpResolver{
+			endpoints: endpoints,
+			client:    &http.Client{Timeout: config.PublicIPTimeout},
+		}
+	default:
+		return nil, fmt.Errorf("unknown PUBLIC_IP_MODE %q", config.PublicIPMode)
+	}
+
+	return &cachedResolver{resolver: resolver, ttl: config.PublicIPTTL}, nil
+}
+
+// disabledResolver is used for air-gapped installs
+type disabledResolver struct{}
+
+func (disabledResolver) PublicIP(context.Context) (string, error) {
+	return "", ErrPublicIPDisabled
+}
+
+// staticResolver always returns the configured address
+type staticResolver string
+
+func (r staticResolver) PublicIP(context.Context) (string, error) {
+	return string(r), nil
+}
+
+// maxPublicIPBody bounds an echo endpoint's answer; an address never needs
+// more than a few dozen bytes
+const maxPublicIPBody = 128
+
+// httpResolver asks a list of echo endpoints for our address, in order,
+// returning the first answer that parses as an IP
+type httpResolver struct {
+	endpoints []string
+	client    *http.Client
+}
+
+func (r *httpResolver) PublicIP(ctx context.Context) (string, error) {
+	var lastErr error
+	for _, endpoint := range r.endpoints {
+		ip, err := r.fetch(ctx, endpoint)
+		if err == nil {
+			return ip, nil
+		}
+		log.Printf("Public IP endpoint %s failed: %v", endpoint, err)
+		lastErr = err
+	}
+	return "", fmt.Errorf("all public IP endpoints failed: %w", lastErr)
+}
+
+func (r *httpResolver) fetch(ctx context.Context, endpoint string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPublicIPBody+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > maxPublicIPBody {
+		return "", fmt.Errorf("response is longer than %d bytes", maxPublicIPBody)
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return "", fmt.Errorf("response is not an IP address")
+	}
+	return ip.String(), nil
+}
+
+// publicIPFailureTTL is how long a failed discovery is remembered, so an
+// outage of the echo services does not cost a fetch on every request
+const publicIPFailureTTL = 10 * time.Second
+
+// cachedResolver remembers an answer for ttl, and a failure for
+// publicIPFailureTTL. Concurrent callers share a single refresh, which runs
+// outside the lock so cached answers are never held up by it.
+type cachedResolver struct {
+	resolver PublicIPResolver
+	ttl      time.Duration
+	refresh  singleflight.Group
+
+	mu      sync.Mutex
+	ip      string
+	err     error
+	expires time.Time
+}
+
+func (r *cachedResolver) PublicIP(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	ip, err, fresh := r.ip, r.err, time.Now().Before(r.expires)
+	r.mu.Unlock()
+	if fresh {
+		return ip, err
+	}
+
+	// The refresh is shared, so it must not end with the caller that
+	// started it; the client's timeout bounds it instead
+	ch := r.refresh.DoChan("", func() (interface{}, error) {
+		ip, err := r.resolver.PublicIP(context.Background())
+
+		ttl := r.ttl
+		if err != nil {
+			ttl = publicIPFailureTTL
+		}
+		r.mu.Lock()
+		r.ip, r.err, r.expires = ip, err, time.Now().Add(ttl)
+		r.mu.Unlock()
+		return ip, err
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return "", res.Err
+		}
+		return res.Val.(string), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingResolver answers after release is closed and counts its calls
+type countingResolver struct {
+	calls   atomic.Int32
+	release chan struct{}
+	ip      string
+	err     error
+}
+
+func (r *countingResolver) PublicIP(context.Context) (string, error) {
+	r.calls.Add(1)
+	<-r.release
+	return r.ip, r.err
+}
+
+func TestCachedResolverSharesRefresh(t *testing.T) {
+	upstream := &countingResolver{release: make(chan struct{}), ip: "203.0.113.7"}
+	r := &cachedResolver{resolver: upstream, ttl: time.Minute}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ip, err := r.PublicIP(context.Background()); err != nil || ip != "203.0.113.7" {
+				t.Errorf("PublicIP() = %q, %v", ip, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(upstream.release)
+	wg.Wait()
+
+	if ip, _ := r.PublicIP(context.Background()); ip != "203.0.113.7" {
+		t.Errorf("cached PublicIP() = %q", ip)
+	}
+	if n := upstream.calls.Load(); n != 1 {
+		t.Errorf("upstream called %d times, want 1", n)
+	}
+}
+
+func TestCachedResolverCachesFailures(t *testing.T) {
+	upstream := &countingResolver{release: make(chan struct{}), err: errors.New("unreachable")}
+	close(upstream.release)
+	r := &cachedResolver{resolver: upstream, ttl: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.PublicIP(context.Background()); err == nil {
+			t.Fatal("PublicIP() succeeded")
+		}
+	}
+	if n := upstream.calls.Load(); n != 1 {
+		t.Errorf("upstream called %d times, want 1", n)
+	}
+}
+
+func TestCachedResolverHonorsContext(t *testing.T) {
+	upstream := &countingResolver{release: make(chan struct{}), ip: "203.0.113.7"}
+	defer close(upstream.release)
+	r := &cachedResolver{resolver: upstream, ttl: time.Minute}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := r.PublicIP(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PublicIP() error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestHTTPResolver(t *testing.T) {
+	serve := func(status int, body string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+			io.WriteString(w, body)
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+	ok := serve(http.StatusOK, "203.0.113.7\n")
+
+	tests := []struct {
+		name      string
+		endpoints []string
+		want      string
+	}{
+		{"first", []string{ok, serve(http.StatusOK, "198.51.100.1")}, "203.0.113.7"},
+		{"ipv6", []string{serve(http.StatusOK, " 2001:DB8::1 ")}, "2001:db8::1"},
+		{"failover after an error status", []string{serve(http.StatusServiceUnavailable, "198.51.100.1"), ok}, "203.0.113.7"},
+		{"failover after a non-IP body", []string{serve(http.StatusOK, "<html>rate limited</html>"), ok}, "203.0.113.7"},
+		{"failover after an oversized body", []string{serve(http.StatusOK, "198.51.100.1"+strings.Repeat(" ", maxPublicIPBody)), ok}, "203.0.113.7"},
+		{"failover after an unreachable endpoint", []string{"http://127.0.0.1:1", ok}, "203.0.113.7"},
+		{"all failed", []string{serve(http.StatusNotFound, ""), serve(http.StatusOK, "")}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &httpResolver{endpoints: tt.endpoints, client: &http.Client{Timeout: time.Second}}
+			ip, err := r.PublicIP(context.Background())
+			if ip != tt.want || (err == nil) != (tt.want != "") {
+				t.Errorf("PublicIP() = %q, %v; want %q", ip, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPublicIPResolver(t *testing.T) {
+	tests := []struct {
+		config Config
+		want   string
+		err    bool
+	}{
+		{config: Config{PublicIPMode: PublicIPModeStatic, PublicIP: "203.0.113.7"}, want: "203.0.113.7"},
+		// The address is canonicalised
+		{config: Config{PublicIPMode: PublicIPModeStatic, PublicIP: "2001:DB8:0::1"}, want: "2001:db8::1"},
+		{config: Config{PublicIPMode: PublicIPModeStatic, PublicIP: ""}, err: true},
+		{config: Config{PublicIPMode: PublicIPModeStatic, PublicIP: "203.0.113.7/32"}, err: true},
+		{config: Config{PublicIPMode: PublicIPModeStatic, PublicIP: "example.com"}, err: true},
+		{config: Config{PublicIPMode: "dns"}, err: true},
+	}
+	for _, tt := range tests {
+		r, err := newPublicIPResolver(&tt.config)
+		if tt.err {
+			if err == nil {
+				t.Errorf("newPublicIPResolver(%+v) succeeded", tt.config)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("newPublicIPResolver(%+v) = %v", tt.config, err)
+			continue
+		}
+		if ip, err := r.PublicIP(context.Background()); ip != tt.want || err != nil {
+			t.Errorf("PublicIP() = %q, %v; want %q", ip, err, tt.want)
+		}
+	}
+
+	r, err := newPublicIPResolver(&Config{PublicIPMode: PublicIPModeOff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.PublicIP(context.Background()); !errors.Is(err, ErrPublicIPDisabled) {
+		t.Errorf("PublicIP() error = %v, want %v", err, ErrPublicIPDisabled)
+	}
+}
@@ -0,0 +1,306 @@
+package resolver
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+var (
+	ErrInvalidHost = errors.New("invalid hostname")
+	ErrNotFound    = errors.New("host not found")
+)
+
+// Record is a single address returned for a host
+type Record struct {
+	IP   netip.Addr `json:"ip"`
+	Type string     `json:"type"`
+	TTL  uint32     `json:"ttl"`
+}
+
+// Resolver is a minimal stub resolver that queries a single DNS server
+// directly, so that record TTLs are available to callers. Point Server at a
+// local stub to exercise it without network access.
+type Resolver struct {
+	Server  string
+	Timeout time.Duration
+}
+
+// New creates a resolver for server ("host:port"). An empty server uses the
+// first nameserver from /etc/resolv.conf.
+func New(server string, timeout time.Duration) *Resolver {
+	if server == "" {
+		server = SystemServer()
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	return &Resolver{Server: server, Timeout: timeout}
+}
+
+// NetResolver returns a *net.Resolver that sends its queries to the same
+// server, for lookups this package does not implement, such as PTR
+func (r *Resolver) NetResolver() *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, r.Server)
+		},
+	}
+}
+
+// SystemServer returns the first nameserver listed in /etc/resolv.conf,
+// falling back to the local host
+func SystemServer() string {
+	f, err := os.Open("/etc/resolv.conf")
+	if err != nil {
+		return "127.0.0.1:53"
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			return net.JoinHostPort(fields[1], "53")
+		}
+	}
+	return "127.0.0.1:53"
+}
+
+// IsHostname reports whether s is a syntactically valid, fully qualified
+// host name
+func IsHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if len(s) == 0 || len(s) > 253 || !strings.Contains(s, ".") {
+		return false
+	}
+
+	labels := strings.Split(s, ".")
+	if tld := labels[len(labels)-1]; isDigits(tld) {
+		// An all-numeric TLD is a mistyped IP address, not a host
+		return false
+	}
+
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Lookup resolves the A and AAAA records of host concurrently. A CNAME chain
+// is followed by the server; only the final addresses are returned.
+func (r *Resolver) Lookup(ctx context.Context, host string) ([]Record, error) {
+	if !IsHostname(host) {
+		return nil, ErrInvalidHost
+	}
+
+	name, err := dnsmessage.NewName(strings.TrimSuffix(host, ".") + ".")
+	if err != nil {
+		return nil, ErrInvalidHost
+	}
+
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results [2][]Record
+		errs    [2]error
+	)
+
+	for i, qtype := range []dnsmessage.Type{dnsmessage.TypeA, dnsmessage.TypeAAAA} {
+		wg.Add(1)
+		go func(i int, qtype dnsmessage.Type) {
+			defer wg.Done()
+			results[i], errs[i] = r.query(ctx, name, qtype)
+		}(i, qtype)
+	}
+	wg.Wait()
+
+	records := append(results[0], results[1]...)
+	if len(records) > 0 {
+		return records, nil
+	}
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *Resolver) query(ctx context.Context, name dnsmessage.Name, qtype dnsmessage.Type) ([]Record, error) {
+	id := uint16(rand.Intn(1 << 16))
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: name, Type: qtype, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	msg, err := b.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.exchange(ctx, "udp", msg)
+	if err != nil {
+		return nil, err
+	}
+
+	records, truncated, err := parseResponse(resp, id, name, qtype)
+	if truncated {
+		if resp, err = r.exchange(ctx, "tcp", msg); err != nil {
+			return nil, err
+		}
+		records, _, err = parseResponse(resp, id, name, qtype)
+	}
+	return records, err
+}
+
+// exchange sends msg to the server and returns the raw answer
+func (r *Resolver) exchange(ctx context.Context, network string, msg []byte) ([]byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, r.Server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if network == "udp" {
+		if _, err := conn.Write(msg); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, 4096)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+
+	// DNS over TCP prefixes every message with its length
+	framed := make([]byte, 2+len(msg))
+	binary.BigEndian.PutUint16(framed, uint16(len(msg)))
+	copy(framed[2:], msg)
+	if _, err := conn.Write(framed); err != nil {
+		return nil, err
+	}
+
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func parseResponse(resp []byte, id uint16, name dnsmessage.Name, qtype dnsmessage.Type) ([]Record, bool, error) {
+	var p dnsmessage.Parser
+	header, err := p.Start(resp)
+	if err != nil {
+		return nil, false, err
+	}
+	if header.ID != id || !header.Response {
+		return nil, false, fmt.Errorf("mismatched DNS response")
+	}
+	if header.Truncated {
+		return nil, true, nil
+	}
+
+	switch header.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return nil, false, ErrNotFound
+	default:
+		return nil, false, fmt.Errorf("DNS server returned %s", header.RCode)
+	}
+
+	question, err := p.Question()
+	if err != nil {
+		return nil, false, err
+	}
+	if !strings.EqualFold(question.Name.String(), name.String()) || question.Type != qtype {
+		return nil, false, fmt.Errorf("mismatched DNS question")
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return nil, false, err
+	}
+
+	var records []Record
+	for {
+		h, err := p.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch h.Type {
+		case dnsmessage.TypeA:
+			a, err := p.AResource()
+			if err != nil {
+				return nil, false, err
+			}
+			records = append(records, Record{IP: netip.AddrFrom4(a.A), Type: "A", TTL: h.TTL})
+		case dnsmessage.TypeAAAA:
+			aaaa, err := p.AAAAResource()
+			if err != nil {
+				return nil, false, err
+			}
+			records = append(records, Record{IP: netip.AddrFrom16(aaaa.AAAA), Type: "AAAA", TTL: h.TTL})
+		default:
+			if err := p.SkipAnswer(); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, false, ErrNotFound
+	}
+	return records, false, nil
+}
@@ -0,0 +1,114 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/imnitish-dev/ip2location/internal/dnstest"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestLookup(t *testing.T) {
+	s := dnstest.Start(t, &dnstest.Server{
+		Zone: map[string][]dnsmessage.Resource{
+			"dual.example.":  {dnstest.A("dual.example.", "192.0.2.1", 300), dnstest.AAAA("dual.example.", "2001:db8::1", 60)},
+			"v4.example.":    {dnstest.A("v4.example.", "192.0.2.2", 30), dnstest.A("v4.example.", "192.0.2.3", 30)},
+			"big.example.":   {dnstest.A("big.example.", "192.0.2.4", 120)},
+			"empty.example.": nil,
+		},
+		Truncate: map[string]bool{"big.example.": true},
+	})
+	r := New(s.Addr, time.Second)
+
+	tests := []struct {
+		host string
+		want []Record
+		err  error
+	}{
+		{
+			host: "dual.example",
+			want: []Record{
+				{IP: netip.MustParseAddr("192.0.2.1"), Type: "A", TTL: 300},
+				{IP: netip.MustParseAddr("2001:db8::1"), Type: "AAAA", TTL: 60},
+			},
+		},
+		{
+			host: "v4.example.",
+			want: []Record{
+				{IP: netip.MustParseAddr("192.0.2.2"), Type: "A", TTL: 30},
+				{IP: netip.MustParseAddr("192.0.2.3"), Type: "A", TTL: 30},
+			},
+		},
+		{
+			host: "big.example",
+			want: []Record{{IP: netip.MustParseAddr("192.0.2.4"), Type: "A", TTL: 120}},
+		},
+		{host: "missing.example", err: ErrNotFound},
+		{host: "empty.example", err: ErrNotFound},
+		{host: "localhost", err: ErrInvalidHost},
+		{host: "192.168.1.300", err: ErrInvalidHost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			got, err := r.Lookup(context.Background(), tt.host)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Lookup() error = %v, want %v", err, tt.err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Lookup() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("record %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if s.TCPQueries.Load() == 0 {
+		t.Error("truncated answer was not retried over TCP")
+	}
+}
+
+func TestLookupTimeout(t *testing.T) {
+	s := dnstest.Start(t, &dnstest.Server{
+		Zone:   map[string][]dnsmessage.Resource{"slow.example.": {dnstest.A("slow.example.", "192.0.2.1", 60)}},
+		Silent: map[string]bool{"slow.example.": true},
+	})
+	r := New(s.Addr, 50*time.Millisecond)
+
+	start := time.Now()
+	_, err := r.Lookup(context.Background(), "slow.example")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Lookup() error = %v, want a timeout", err)
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Lookup() error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Lookup() took %v", elapsed)
+	}
+}
+
+func TestIsHostname(t *testing.T) {
+	tests := map[string]bool{
+		"example.com":      true,
+		"example.com.":     true,
+		"_srv.example.com": true,
+		"localhost":        false,
+		"-bad.example.com": false,
+		"a..example.com":   false,
+		"10.0.0.1":         false,
+		"example.c om":     false,
+	}
+	for host, want := range tests {
+		if got := IsHostname(host); got != want {
+			t.Errorf("IsHostname(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
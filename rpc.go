@@ -0,0 +1,526 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	pb "github.com/imnitish-dev/ip2location/proto"
+	"github.com/imnitish-dev/ip2location/proto/protoconnect"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"github.com/imnitish-dev/ip2location/proto/v2/ip2locationv2connect"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// clientIPKey is the Locals key holding the caller's address for RPCs
+// served through the net/http adaptor
+type clientIPKey struct{}
+
+// rpcHeaders are the response headers Connect and gRPC-Web clients in
+// browsers need to read
+var rpcHeaders = strings.Join([]string{
+	"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin",
+}, ", ")
+
+// connectServer adapts GRPCServer to the handler interface of connect-go,
+// which serves the Connect protocol and gRPC-Web. Native gRPC needs HTTP/2
+// and stays on GRPC_PORT.
+type connectServer struct {
+	server *GRPCServer
+}
+
+// LookupIP implements protoconnect.IP2LocationServiceHandler
+func (s connectServer) LookupIP(ctx context.Context, req *connect.Request[pb.LookupRequest]) (*connect.Response[pb.LookupResponse], error) {
+	res, err := s.server.LookupIP(incomingContext(ctx, req.Header()), req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// ParseUserAgent implements protoconnect.IP2LocationServiceHandler
+func (s connectServer) ParseUserAgent(ctx context.Context, req *connect.Request[pb.ParseUserAgentRequest]) (*connect.Response[pb.ParseUserAgentResponse], error) {
+	res, err := s.server.ParseUserAgent(incomingContext(ctx, req.Header()), req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// connectServerV2 is connectServer for the ip2location.v2 service
+type connectServerV2 struct {
+	server *GRPCServerV2
+}
+
+// LookupIP implements ip2locationv2connect.IP2LocationServiceHandler
+func (s connectServerV2) LookupIP(ctx context.Context, req *connect.Request[pbv2.LookupRequest]) (*connect.Response[pbv2.LookupResponse], error) {
+	res, err := s.server.LookupIP(incomingContext(ctx, req.Header()), req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// ParseUserAgent implements ip2locationv2connect.IP2LocationServiceHandler
+func (s connectServerV2) ParseUserAgent(ctx context.Context, req *connect.Request[pbv2.ParseUserAgentRequest]) (*connect.Response[pbv2.ParseUserAgentResponse], error) {
+	res, err := s.server.ParseUserAgent(incomingContext(ctx, req.Header()), req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// GetCountry implements ip2locationv2connect.IP2LocationServiceHandler
+func (s connectServerV2) GetCountry(ctx context.Context, req *connect.Request[pbv2.GetCountryRequest]) (*connect.Response[pbv2.GetCountryResponse], error) {
+	res, err := s.server.GetCountry(incomingContext(ctx, req.Header()), req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// Decide implements ip2locationv2connect.IP2LocationServiceHandler
+func (s connectServerV2) Decide(ctx context.Context, req *connect.Request[pbv2.DecideRequest]) (*connect.Response[pbv2.DecideResponse], error) {
+	res, err := s.server.Decide(incomingContext(ctx, req.Header()), req.Msg)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// incomingContext makes an HTTP request look like a gRPC call to the
+// service: headers become incoming metadata and the client IP stored under
+// clientIPKey becomes the peer address
+func incomingContext(ctx context.Context, header http.Header) context.Context {
+	md := metadata.MD{}
+	for name, values := range header {
+		md.Append(strings.ToLower(name), values...)
+	}
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	if ip, ok := ctx.Value(clientIPKey{}).(string); ok && ip != "" {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.IPAddr{IP: net.ParseIP(ip)}})
+	}
+	return ctx
+}
+
+// connectError converts a gRPC status error; both use the same codes
+func connectError(err error) error {
+	st := status.Convert(err)
+	return connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+}
+
+// httpBinding is one google.api.http route of a method
+type httpBinding struct {
+	method protoreflect.MethodDescriptor
+	verb   string
+	path   string   // Fiber route path
+	params []string // path variables, as field paths
+	body   string   // "*", a field name, or empty for query parameters
+}
+
+// httpBindings reads the google.api.http annotations of a service
+func httpBindings(service protoreflect.ServiceDescriptor) ([]httpBinding, error) {
+	var bindings []httpBinding
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+
+		for _, r := range append([]*annotations.HttpRule{rule}, rule.AdditionalBindings...) {
+			binding, err := newHTTPBinding(method, r)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", method.FullName(), err)
+			}
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
+
+func newHTTPBinding(method protoreflect.MethodDescriptor, rule *annotations.HttpRule) (httpBinding, error) {
+	binding := httpBinding{method: method, body: rule.Body}
+
+	var template string
+	switch pattern := rule.Pattern.(type) {
+	case *annotations.HttpRule_Get:
+		binding.verb, template = fiber.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		binding.verb, template = fiber.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		binding.verb, template = fiber.MethodPut, pattern.Put
+	case *annotations.HttpRule_Patch:
+		binding.verb, template = fiber.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Delete:
+		binding.verb, template = fiber.MethodDelete, pattern.Delete
+	default:
+		return binding, fmt.Errorf("unsupported HTTP rule %v", rule)
+	}
+
+	// Only single-segment variables, {field} or {field=*}, are supported
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") {
+			continue
+		}
+		field := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}"), "=*")
+		if strings.ContainsAny(field, "{}=*.") {
+			return binding, fmt.Errorf("unsupported path template %q", template)
+		}
+		segments[i] = ":" + field
+		binding.params = append(binding.params, field)
+	}
+	binding.path = strings.Join(segments, "/")
+	return binding, nil
+}
+
+// rpcService is a gRPC service served on the HTTP port
+type rpcService struct {
+	desc   protoreflect.ServiceDescriptor
+	grpc   grpc.ServiceDesc
+	server interface{}
+	// connect returns the Connect handler and the path it is mounted at
+	connect func() (string, http.Handler)
+}
+
+// rpcServices lists the gRPC services, oldest version first
+func (a *App) rpcServices() []rpcService {
+	server := &GRPCServer{app: a}
+	serverV2 := &GRPCServerV2{app: a}
+	return []rpcService{
+		{
+			desc:   pb.File_proto_ip2location_proto.Services().ByName("IP2LocationService"),
+			grpc:   pb.IP2LocationService_ServiceDesc,
+			server: server,
+			connect: func() (string, http.Handler) {
+				return protoconnect.NewIP2LocationServiceHandler(connectServer{server})
+			},
+		},
+		{
+			desc:   pbv2.File_proto_v2_ip2location_proto.Services().ByName("IP2LocationService"),
+			grpc:   pbv2.IP2LocationService_ServiceDesc,
+			server: serverV2,
+			connect: func() (string, http.Handler) {
+				return ip2locationv2connect.NewIP2LocationServiceHandler(connectServerV2{serverV2})
+			},
+		},
+	}
+}
+
+// setupRPCRoutes serves the gRPC services on the HTTP port: every method
+// at its Connect procedure path, for the Connect protocol and gRPC-Web, and
+// at the routes of its google.api.http annotations with JSON transcoding.
+// Transcoded /v1 routes are also served unversioned, as deprecated
+// aliases. It returns the operations it registered, for the OpenAPI
+// document.
+func (a *App) setupRPCRoutes() ([]operation, error) {
+	var ops []operation
+
+	corsHandler := cors.New(cors.Config{ExposeHeaders: rpcHeaders})
+	withClientIP := func(h fiber.Handler) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals(clientIPKey{}, getClientIP(c))
+			return h(c)
+		}
+	}
+	preflight := make(map[string]bool)
+	route := func(op operation, handlers ...fiber.Handler) {
+		if !preflight[op.Path] {
+			a.fiber.Options(op.Path, corsHandler)
+			preflight[op.Path] = true
+		}
+		a.fiber.Add(op.Method, op.Path, append([]fiber.Handler{corsHandler}, handlers...)...)
+		ops = append(ops, op)
+	}
+
+	for _, service := range a.rpcServices() {
+		prefix, handler := service.connect()
+		connectHandler := withClientIP(adaptor.HTTPHandler(handler))
+		methods := service.desc.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			route(operation{
+				Method:  fiber.MethodPost,
+				Path:    prefix + string(method.Name()),
+				Summary: fmt.Sprintf("%s over Connect or gRPC-Web", method.FullName()),
+				RPC:     method,
+				Connect: true,
+			}, connectHandler)
+		}
+
+		bindings, err := httpBindings(service.desc)
+		if err != nil {
+			return nil, err
+		}
+		for _, binding := range bindings {
+			binding := binding
+			desc, err := grpcMethod(service.grpc, binding.method.Name())
+			if err != nil {
+				return nil, err
+			}
+
+			handler := withClientIP(transcode(service.server, binding, desc))
+			op := operation{
+				Method:  binding.verb,
+				Path:    binding.path,
+				Summary: fmt.Sprintf("%s with JSON transcoding", binding.method.FullName()),
+				RPC:     binding.method,
+				Binding: &binding,
+			}
+			route(op, rpcRequestTimeout, handler)
+
+			if alias := strings.TrimPrefix(binding.path, "/v1"); alias != binding.path {
+				op.Path = alias
+				op.Deprecated = true
+				route(op, a.deprecated, rpcRequestTimeout, handler)
+			}
+		}
+	}
+	return ops, nil
+}
+
+// grpcMethod finds the unary handler of a method in a service description
+func grpcMethod(service grpc.ServiceDesc, name protoreflect.Name) (grpc.MethodDesc, error) {
+	for _, method := range service.Methods {
+		if method.MethodName == string(name) {
+			return method, nil
+		}
+	}
+	return grpc.MethodDesc{}, fmt.Errorf("%s: no unary method %s", service.ServiceName, name)
+}
+
+// transcode serves one google.api.http binding: the request message is
+// built from the path variables, the JSON body and the query parameters,
+// and the response is written as JSON
+func transcode(server interface{}, binding httpBinding, desc grpc.MethodDesc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req, err := transcodeRequest(c, binding)
+		if err != nil {
+			return writeStatus(c, status.New(codes.InvalidArgument, err.Error()))
+		}
+
+		// The user context carries the request's deadline but not its
+		// Locals, so the client IP is copied across for the peer
+		ctx := context.WithValue(c.UserContext(), clientIPKey{}, c.Locals(clientIPKey{}))
+		ctx = incomingContext(ctx, http.Header(c.GetReqHeaders()))
+		res, err := desc.Handler(server, ctx, func(v interface{}) error {
+			proto.Merge(v.(proto.Message), req)
+			return nil
+		}, nil)
+		if err != nil {
+			return writeStatus(c, status.Convert(err))
+		}
+
+		body, err := protojson.Marshal(res.(proto.Message))
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	}
+}
+
+// writeStatus writes a gRPC status as a google.rpc.Status JSON object
+func writeStatus(c *fiber.Ctx, st *status.Status) error {
+	body, err := protojson.Marshal(st.Proto())
+	if err != nil {
+		return err
+	}
+	c.Status(httpStatus(st.Code()))
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(body)
+}
+
+// transcodeRequest builds the request message of a binding from c
+func transcodeRequest(c *fiber.Ctx, binding httpBinding) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(binding.method.Input().FullName())
+	if err != nil {
+		return nil, err
+	}
+	req := mt.New()
+
+	if body := c.Body(); binding.body != "" && len(body) > 0 {
+		target := req
+		if binding.body != "*" {
+			field := req.Descriptor().Fields().ByName(protoreflect.Name(binding.body))
+			if field == nil || field.Message() == nil {
+				return nil, fmt.Errorf("unsupported body field %q", binding.body)
+			}
+			target = req.Mutable(field).Message()
+		}
+		if err := protojson.Unmarshal(body, target.Interface()); err != nil {
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+
+	for _, param := range binding.params {
+		value, err := url.PathUnescape(c.Params(param))
+		if err != nil {
+			return nil, err
+		}
+		if err := setField(req, param, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if binding.body != "*" {
+		c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+			if err == nil {
+				err = setField(req, string(key), string(value))
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return req.Interface(), nil
+}
+
+// setField sets the field at a dotted path, given by proto or JSON names,
+// from its string form. Repeated fields are appended to, and field masks
+// take comma-separated paths.
+func setField(msg protoreflect.Message, path, value string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fields := msg.Descriptor().Fields()
+		field := fields.ByName(protoreflect.Name(name))
+		if field == nil {
+			field = fields.ByJSONName(name)
+		}
+		if field == nil || field.IsMap() {
+			return fmt.Errorf("unknown field %q", path)
+		}
+
+		if i < len(names)-1 {
+			if field.Message() == nil || field.IsList() {
+				return fmt.Errorf("unknown field %q", path)
+			}
+			msg = msg.Mutable(field).Message()
+			continue
+		}
+
+		if field.Message() != nil && field.Message().FullName() == "google.protobuf.FieldMask" {
+			mask := msg.Mutable(field).Message().Interface().(*fieldmaskpb.FieldMask)
+			for _, p := range strings.Split(value, ",") {
+				if p = strings.TrimSpace(p); p != "" {
+					mask.Paths = append(mask.Paths, p)
+				}
+			}
+			return nil
+		}
+
+		v, err := parseValue(msg, field, value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %w", path, err)
+		}
+		if field.IsList() {
+			msg.Mutable(field).List().Append(v)
+		} else {
+			msg.Set(field, v)
+		}
+	}
+	return nil
+}
+
+// parseValue parses the string form of a singular value of field
+func parseValue(msg protoreflect.Message, field protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.BytesKind:
+		v, err := base64.URLEncoding.DecodeString(s)
+		if err != nil {
+			v, err = base64.StdEncoding.DecodeString(s)
+		}
+		return protoreflect.ValueOfBytes(v), err
+	case protoreflect.EnumKind:
+		if value := field.Enum().Values().ByName(protoreflect.Name(s)); value != nil {
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+		v, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), err
+	default:
+		// Well-known types with a JSON string form, such as Timestamp
+		v := msg.NewField(field)
+		if field.IsList() {
+			v = msg.Mutable(field).List().NewElement()
+		}
+		err := protojson.Unmarshal([]byte(strconv.Quote(s)), v.Message().Interface())
+		return v, err
+	}
+}
+
+// httpStatus maps a gRPC status code to the HTTP status of a transcoded
+// response
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return fiber.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return fiber.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return fiber.StatusGatewayTimeout
+	case codes.NotFound:
+		return fiber.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return fiber.StatusConflict
+	case codes.PermissionDenied:
+		return fiber.StatusForbidden
+	case codes.Unauthenticated:
+		return fiber.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return fiber.StatusTooManyRequests
+	case codes.Unimplemented:
+		return fiber.StatusNotImplemented
+	case codes.Unavailable:
+		return fiber.StatusServiceUnavailable
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
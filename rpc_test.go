@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/ip2location"
+	pb "github.com/imnitish-dev/ip2location/proto"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestSetField(t *testing.T) {
+	req := &pbv2.LookupRequest{}
+	msg := req.ProtoReflect()
+	for path, value := range map[string]string{
+		"ip":         "192.0.2.1",
+		"userAgent":  "curl/8.0",
+		"field_mask": "providers.maxmind.city, device ,",
+	} {
+		if err := setField(msg, path, value); err != nil {
+			t.Fatalf("setField(%s) = %v", path, err)
+		}
+	}
+	// Field masks add to the paths already set
+	if err := setField(msg, "fieldMask", "ip"); err != nil {
+		t.Fatal(err)
+	}
+	want := &pbv2.LookupRequest{Ip: "192.0.2.1", UserAgent: "curl/8.0"}
+	want.FieldMask = &fieldmaskpb.FieldMask{Paths: []string{"providers.maxmind.city", "device", "ip"}}
+	if !proto.Equal(req, want) {
+		t.Errorf("setField() built %v, want %v", req, want)
+	}
+
+	// Scalars, enums, nested and repeated fields, on a message that has them
+	field := &descriptorpb.DescriptorProto{}
+	for path, value := range map[string]string{
+		"name":               "Location",
+		"options.deprecated": "true",
+		"options.map_entry":  "0",
+	} {
+		if err := setField(field.ProtoReflect(), path, value); err != nil {
+			t.Errorf("setField(%s, %q) = %v", path, value, err)
+		}
+	}
+	// Repeated fields are appended to
+	for _, name := range []string{"reserved_name", "reservedName"} {
+		if err := setField(field.ProtoReflect(), name, name[:1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if field.GetName() != "Location" || !field.GetOptions().GetDeprecated() || strings.Join(field.ReservedName, ",") != "r,r" {
+		t.Errorf("setField() built %v", field)
+	}
+	fd := &descriptorpb.FieldDescriptorProto{}
+	for path, value := range map[string]string{"number": "7", "label": "LABEL_REPEATED", "type": "9"} {
+		if err := setField(fd.ProtoReflect(), path, value); err != nil {
+			t.Errorf("setField(%s, %q) = %v", path, value, err)
+		}
+	}
+	if fd.GetNumber() != 7 || fd.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED || fd.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		t.Errorf("setField() built %v", fd)
+	}
+
+	bad := []struct {
+		msg         proto.Message
+		path, value string
+	}{
+		{&pbv2.LookupRequest{}, "nope", "1"},
+		{&pbv2.LookupRequest{}, "ip.value", "1"},
+		{&pbv2.LookupRequest{}, "field_mask.paths.x", "1"},
+		{&pbv2.DecideRequest{}, "hints", "a"},
+		{&pbv2.DecideRequest{}, "hints.sec-ch-ua", "a"},
+		{&descriptorpb.FieldDescriptorProto{}, "number", "seven"},
+		{&descriptorpb.FieldDescriptorProto{}, "number", "4294967296"},
+		{&descriptorpb.FieldDescriptorProto{}, "label", "LABEL_SOMETIMES"},
+		{&descriptorpb.FieldDescriptorProto{}, "options.packed", "maybe"},
+		// Repeated messages cannot be descended into
+		{&descriptorpb.DescriptorProto{}, "field.name", "a"},
+	}
+	for _, tt := range bad {
+		if err := setField(tt.msg.ProtoReflect(), tt.path, tt.value); err == nil {
+			t.Errorf("setField(%T, %s, %q) succeeded", tt.msg, tt.path, tt.value)
+		}
+	}
+}
+
+// newRPCApp serves every route over the MaxMind and IP2Location stubs
+func newRPCApp(t *testing.T) *App {
+	t.Helper()
+	located := &stubLocator{location: &ip2location.Location{Country: "United States", CountryCode: "US", City: "Mountain View"}}
+	a := newTestApp(t, located, located)
+	a.fiber = fiber.New(fiber.Config{ErrorHandler: errorHandler, JSONEncoder: json.Marshal, JSONDecoder: json.Unmarshal})
+	a.setupRoutes()
+	if _, err := a.setupRPCRoutes(); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func send(t *testing.T, a *App, method, target, contentType string, body []byte) (*http.Response, []byte) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set(fiber.HeaderContentType, contentType)
+	}
+	res, err := a.fiber.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res, data
+}
+
+func TestTranscoding(t *testing.T) {
+	a := newRPCApp(t)
+
+	lookups := []struct {
+		method, target, body string
+		city                 string
+	}{
+		{method: "GET", target: "/v2/rpc/lookup/192.0.2.1", city: "Mountain View"},
+		{method: "GET", target: "/v2/rpc/lookup/192.0.2.1?field_mask=ip", city: ""},
+		{method: "GET", target: "/v2/rpc/lookup?ip=192.0.2.1&fieldMask=ip,providers.maxmind.city", city: "Mountain View"},
+		{method: "POST", target: "/v2/rpc/lookup", body: `{"ip": "192.0.2.1", "fieldMask": "ip,providers.maxmind"}`, city: "Mountain View"},
+	}
+	for _, tt := range lookups {
+		res, body := send(t, a, tt.method, tt.target, fiber.MIMEApplicationJSON, []byte(tt.body))
+		var got pbv2.LookupResponse
+		if err := protojson.Unmarshal(body, &got); err != nil || res.StatusCode != fiber.StatusOK {
+			t.Errorf("%s %s: got %d %s", tt.method, tt.target, res.StatusCode, body)
+			continue
+		}
+		if got.Ip != "192.0.2.1" || got.GetProviders().GetMaxmind().GetCity() != tt.city || got.Error != nil {
+			t.Errorf("%s %s: got %s", tt.method, tt.target, body)
+		}
+	}
+
+	// Malformed requests fail with INVALID_ARGUMENT as a google.rpc.Status
+	malformed := []struct {
+		method, target, body string
+	}{
+		{method: "GET", target: "/v2/rpc/lookup?nope=1"},
+		{method: "GET", target: "/v2/rpc/lookup?ip.value=1"},
+		{method: "GET", target: "/v2/rpc/lookup/192.0.2.1?field_mask.nope=ip"},
+		{method: "POST", target: "/v2/rpc/lookup", body: `{"ip": `},
+		{method: "POST", target: "/v2/rpc/lookup", body: `{"ip": 1}`},
+		{method: "POST", target: "/v2/rpc/lookup", body: `{"nope": "1"}`},
+		{method: "POST", target: "/v2/rpc/decide", body: `{"hints": "a"}`},
+		{method: "GET", target: "/v1/rpc/ua?userAgent=a&hints.x=1"},
+	}
+	for _, tt := range malformed {
+		res, body := send(t, a, tt.method, tt.target, fiber.MIMEApplicationJSON, []byte(tt.body))
+		var st struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(body, &st); err != nil || res.StatusCode != fiber.StatusBadRequest || st.Code != 3 || st.Message == "" {
+			t.Errorf("%s %s %s: got %d %s, want 400 with code 3", tt.method, tt.target, tt.body, res.StatusCode, body)
+		}
+	}
+
+	// Requests that parse but are invalid are answered in the response
+	_, body := send(t, a, "GET", "/v2/rpc/lookup/192.0.2.1?field_mask=providers.nope", "", nil)
+	if !strings.Contains(string(body), `"code":"invalid_request"`) {
+		t.Errorf("unknown mask path: got %s", body)
+	}
+	_, body = send(t, a, "GET", "/v2/rpc/lookup/not%20an%20ip", "", nil)
+	if !strings.Contains(string(body), `"code":"invalid_request"`) {
+		t.Errorf("invalid address: got %s", body)
+	}
+}
+
+func TestTranscodingDeprecatedAliases(t *testing.T) {
+	a := newRPCApp(t)
+
+	for _, target := range []string{"/v1/rpc/lookup/192.0.2.1", "/rpc/lookup/192.0.2.1"} {
+		res, body := send(t, a, "GET", target, "", nil)
+		var got pb.LookupResponse
+		if err := protojson.Unmarshal(body, &got); err != nil || got.GetMaxmind().GetCountryCode() != "US" {
+			t.Errorf("%s: got %d %s", target, res.StatusCode, body)
+		}
+		deprecated := res.Header.Get("Deprecation") != ""
+		if want := !strings.HasPrefix(target, "/v1/"); deprecated != want {
+			t.Errorf("%s: deprecated = %v, want %v", target, deprecated, want)
+		}
+	}
+	if res, _ := send(t, a, "GET", "/rpc/ua?userAgent=curl/8.0", "", nil); res.Header.Get(fiber.HeaderLink) != `</v1/rpc/ua>; rel="successor-version"` {
+		t.Errorf("Link = %q", res.Header.Get(fiber.HeaderLink))
+	}
+	// v2 has no unversioned aliases
+	if res, _ := send(t, a, "GET", "/rpc/countries/US", "", nil); res.StatusCode != fiber.StatusNotFound {
+		t.Errorf("/rpc/countries/US: got %d, want 404", res.StatusCode)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	a := newRPCApp(t)
+	const path = "/ip2location.v2.IP2LocationService/LookupIP"
+
+	res, body := send(t, a, "POST", path, "application/json", []byte(`{"ip": "192.0.2.1"}`))
+	var got pbv2.LookupResponse
+	if err := protojson.Unmarshal(body, &got); err != nil || res.StatusCode != fiber.StatusOK || got.Ip != "192.0.2.1" {
+		t.Errorf("Connect JSON: got %d %s", res.StatusCode, body)
+	}
+
+	req, _ := proto.Marshal(&pbv2.LookupRequest{Ip: "192.0.2.1"})
+	res, body = send(t, a, "POST", path, "application/proto", req)
+	got.Reset()
+	if err := proto.Unmarshal(body, &got); err != nil || res.StatusCode != fiber.StatusOK || got.Ip != "192.0.2.1" {
+		t.Errorf("Connect protobuf: got %d %x", res.StatusCode, body)
+	}
+
+	for contentType, body := range map[string][]byte{
+		"application/json":  []byte(`{"ip": `),
+		"application/proto": {0xff, 0xff},
+	} {
+		res, data := send(t, a, "POST", path, contentType, body)
+		var connectErr struct {
+			Code string `json:"code"`
+		}
+		if err := json.Unmarshal(data, &connectErr); err != nil || res.StatusCode != fiber.StatusBadRequest || connectErr.Code != "invalid_argument" {
+			t.Errorf("malformed %s: got %d %s, want 400 invalid_argument", contentType, res.StatusCode, data)
+		}
+	}
+
+	// Connect methods of the first version are served too
+	res, body = send(t, a, "POST", "/ip2location.IP2LocationService/ParseUserAgent", "application/json", []byte(`{"userAgent": "curl/8.0"}`))
+	if res.StatusCode != fiber.StatusOK || !strings.Contains(string(body), "curl") {
+		t.Errorf("v1 Connect: got %d %s", res.StatusCode, body)
+	}
+}
+
+// grpcWebFrame frames a message for gRPC-Web
+func grpcWebFrame(msg []byte) []byte {
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+func TestGRPCWeb(t *testing.T) {
+	a := newRPCApp(t)
+	const path = "/ip2location.v2.IP2LocationService/LookupIP"
+
+	req, _ := proto.Marshal(&pbv2.LookupRequest{Ip: "192.0.2.1"})
+	res, body := send(t, a, "POST", path, "application/grpc-web+proto", grpcWebFrame(req))
+	if res.StatusCode != fiber.StatusOK || len(body) < 5 || body[0] != 0 {
+		t.Fatalf("got %d %x", res.StatusCode, body)
+	}
+	size := binary.BigEndian.Uint32(body[1:5])
+	var got pbv2.LookupResponse
+	if err := proto.Unmarshal(body[5:5+size], &got); err != nil || got.Ip != "192.0.2.1" {
+		t.Errorf("decoded %v, %v", &got, err)
+	}
+	// The status is sent in a trailer frame
+	if trailer := string(body[5+size:]); !strings.Contains(trailer, "grpc-status: 0") {
+		t.Errorf("trailer = %q, want status 0", trailer)
+	}
+
+	res, body = send(t, a, "POST", path, "application/grpc-web+proto", grpcWebFrame([]byte{0xff, 0xff}))
+	// Errors without a message are sent as headers alone
+	if status := res.Header.Get("Grpc-Status"); status != "3" && !strings.Contains(string(body), "grpc-status: 3") {
+		t.Errorf("malformed message: got status %q and %q, want 3", status, body)
+	}
+}
+
+func TestTranscodingTimeout(t *testing.T) {
+	a := newRPCApp(t)
+
+	tests := []struct {
+		target, timeout string
+		status, code    int
+	}{
+		{target: "/v2/rpc/lookup/192.0.2.1", timeout: "5s", status: fiber.StatusOK},
+		{target: "/v2/rpc/lookup/192.0.2.1", timeout: "soon", status: fiber.StatusBadRequest, code: 3},
+		{target: "/v2/rpc/lookup/192.0.2.1", timeout: "1ns", status: fiber.StatusGatewayTimeout, code: 4},
+		{target: "/rpc/lookup/192.0.2.1", timeout: "1ns", status: fiber.StatusGatewayTimeout, code: 4},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.target, nil)
+		req.Header.Set(requestTimeoutHeader, tt.timeout)
+		res, err := a.fiber.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		var st struct {
+			Code int `json:"code"`
+		}
+		if res.StatusCode != tt.status || (tt.code != 0 && (json.Unmarshal(body, &st) != nil || st.Code != tt.code)) {
+			t.Errorf("%s with %s: got %d %s, want %d with code %d", tt.target, tt.timeout, res.StatusCode, body, tt.status, tt.code)
+		}
+	}
+
+	// Without an address the lookup falls back to the client's, which
+	// the handler still sees under the request context
+	_, body := send(t, a, "GET", "/v2/rpc/lookup", "", nil)
+	var got pbv2.LookupResponse
+	if err := protojson.Unmarshal(body, &got); err != nil || got.Ip == "" {
+		t.Errorf("lookup without an address: got %s", body)
+	}
+}
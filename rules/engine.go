@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Engine serves the rules of a file and reloads them when the file changes.
+// A file that fails to load leaves the previous rules in place.
+type Engine struct {
+	path string
+
+	mu      sync.Mutex // serializes reloads
+	modTime time.Time
+	size    int64
+	rules   atomic.Pointer[RuleSet]
+}
+
+// NewEngine loads the rules file at path
+func NewEngine(path string) (*Engine, error) {
+	e := &Engine{path: path}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Path returns the path of the rules file
+func (e *Engine) Path() string {
+	return e.path
+}
+
+// Rules returns the current rules
+func (e *Engine) Rules() *RuleSet {
+	return e.rules.Load()
+}
+
+// Decide evaluates the current rules
+func (e *Engine) Decide(in Input) (Decision, []error) {
+	return e.Rules().Decide(in)
+}
+
+// Reload reads and compiles the rules file, replacing the current rules
+// when it succeeds
+func (e *Engine) Reload() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	f, err := os.Open(e.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// A broken file is reported once, not on every check until it changes
+	e.modTime, e.size = info.ModTime(), info.Size()
+
+	rules, err := Parse(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", e.path, err)
+	}
+
+	e.rules.Store(rules)
+	return nil
+}
+
+// changed reports whether the file was modified since it was last loaded
+func (e *Engine) changed() bool {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !info.ModTime().Equal(e.modTime) || info.Size() != e.size
+}
+
+// Watch reloads the rules on SIGHUP and, when interval is positive, when
+// the file's modification time or size changes. It runs until stop is
+// called.
+func (e *Engine) Watch(interval time.Duration) (stop func()) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	var tick <-chan time.Time
+	var ticker *time.Ticker
+	if interval > 0 {
+		ticker = time.NewTicker(interval)
+		tick = ticker.C
+	}
+
+	done := make(chan struct{})
+	go func() {
+		if ticker != nil {
+			defer ticker.Stop()
+		}
+		for {
+			select {
+			case <-done:
+				return
+			case <-hup:
+			case <-tick:
+				if !e.changed() {
+					continue
+				}
+			}
+			e.reloadAndLog()
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(hup)
+			close(done)
+		})
+	}
+}
+
+func (e *Engine) reloadAndLog() {
+	if err := e.Reload(); err != nil {
+		log.Printf("Failed to reload rules, keeping the previous ones: %v", err)
+		return
+	}
+	log.Printf("Reloaded %d rules from %s", e.Rules().Len(), e.path)
+}
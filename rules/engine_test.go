@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const denyAll = `
+rules:
+  - {name: deny-all, when: 'true', decision: deny}
+`
+
+const reviewAll = `
+rules:
+  - {name: review-all, when: 'true', decision: review, reason: under review}
+`
+
+func writeRules(t *testing.T, path, src string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitFor polls the engine until it decides want
+func waitFor(t *testing.T, e *Engine, want string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got, _ := e.Decide(Input{})
+		if got.Decision == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Decide() = %s, want %s", got.Decision, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestEngineReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeRules(t, path, denyAll)
+
+	e, err := NewEngine(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := e.Watch(10 * time.Millisecond)
+	defer stop()
+	waitFor(t, e, Deny)
+
+	writeRules(t, path, reviewAll)
+	waitFor(t, e, Review)
+
+	// A broken file keeps the rules in force
+	writeRules(t, path, "rules: [")
+	if err := e.Reload(); err == nil {
+		t.Error("Reload() of a broken file succeeded")
+	}
+	if got, _ := e.Decide(Input{}); got.Decision != Review || got.Rule != "review-all" {
+		t.Errorf("Decide() after a broken reload = %+v", got)
+	}
+
+	// and is picked up once fixed
+	writeRules(t, path, denyAll)
+	waitFor(t, e, Deny)
+}
+
+func TestNewEngineErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewEngine(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("NewEngine() of a missing file succeeded")
+	}
+
+	path := filepath.Join(dir, "broken.yaml")
+	writeRules(t, path, `rules: [{name: a, when: 'ip', decision: deny}]`)
+	if _, err := NewEngine(path); err == nil {
+		t.Error("NewEngine() of a file that does not compile succeeded")
+	}
+}
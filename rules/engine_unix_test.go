@@ -0,0 +1,32 @@
+//go:build unix
+
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestEngineSIGHUP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeRules(t, path, denyAll)
+
+	e, err := NewEngine(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Polling is off, so only the signal reloads
+	stop := e.Watch(0)
+	defer stop()
+
+	writeRules(t, path, reviewAll)
+	if got, _ := e.Decide(Input{}); got.Decision != Deny {
+		t.Fatalf("Decide() = %s before SIGHUP", got.Decision)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, e, Review)
+}
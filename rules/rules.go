@@ -0,0 +1,250 @@
+package rules
+
+import (
+	"fmt"
+	"io"
+	"net/netip"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	"gopkg.in/yaml.v3"
+)
+
+// Decisions a rule can make
+const (
+	Allow  = "allow"
+	Deny   = "deny"
+	Review = "review"
+)
+
+// File is the on-disk format of a rules file
+type File struct {
+	// Default is the decision when no rule matches; allow when empty
+	Default string `yaml:"default"`
+	// DryRun makes every rule a dry-run rule
+	DryRun bool   `yaml:"dry_run"`
+	Rules  []Rule `yaml:"rules"`
+}
+
+// Rule makes Decision when the CEL expression When is true. Dry-run rules
+// are evaluated and reported but never decide.
+type Rule struct {
+	Name     string `yaml:"name"`
+	When     string `yaml:"when"`
+	Decision string `yaml:"decision"`
+	// Reason explains the decision to callers
+	Reason string `yaml:"reason"`
+	DryRun bool   `yaml:"dry_run"`
+}
+
+// Input is what rules are evaluated over. Location, Providers and Device
+// are the /v2 JSON forms of the lookup and the parsed user agent; a
+// missing part is an empty map.
+type Input struct {
+	// IP is the address being decided on and ClientIP the caller's
+	IP        string
+	ClientIP  string
+	Location  map[string]interface{}
+	Providers map[string]interface{}
+	Device    map[string]interface{}
+}
+
+// Decision is the outcome of a RuleSet. Rule is empty when the default
+// applied.
+type Decision struct {
+	Decision    string
+	Rule        string
+	Explanation string
+	// DryRun lists the dry-run rules that matched, in order
+	DryRun []Match
+}
+
+// Match is a rule that matched
+type Match struct {
+	Rule        string
+	Decision    string
+	Explanation string
+}
+
+// RuleError is a rule that failed to evaluate
+type RuleError struct {
+	Rule   string
+	DryRun bool
+	Err    error
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("rule %s: %v", e.Rule, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// RuleSet is a compiled rules file
+type RuleSet struct {
+	defaultDecision string
+	rules           []compiled
+}
+
+type compiled struct {
+	Rule
+	program cel.Program
+}
+
+// env declares the variables rules may use and the ip_in function, which
+// reports whether an address is in a CIDR block
+var env = func() *cel.Env {
+	dyn := cel.MapType(cel.StringType, cel.DynType)
+	env, err := cel.NewEnv(
+		cel.Variable("ip", cel.StringType),
+		cel.Variable("client_ip", cel.StringType),
+		cel.Variable("location", dyn),
+		cel.Variable("providers", dyn),
+		cel.Variable("device", dyn),
+		cel.Function("ip_in",
+			cel.Overload("ip_in_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(ipIn))),
+		ext.Strings(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return env
+}()
+
+func ipIn(ip, cidr ref.Val) ref.Val {
+	addr, err := netip.ParseAddr(string(ip.(types.String)))
+	if err != nil {
+		return types.False
+	}
+	prefix, err := netip.ParsePrefix(string(cidr.(types.String)))
+	if err != nil {
+		return types.NewErr("invalid CIDR block %q", cidr.Value())
+	}
+	return types.Bool(prefix.Contains(addr.Unmap()))
+}
+
+// Parse reads and compiles a rules file. Every rule needs a unique name,
+// an expression that evaluates to a bool and a known decision.
+func Parse(r io.Reader) (*RuleSet, error) {
+	var file File
+	if err := yaml.NewDecoder(r).Decode(&file); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+	return Compile(&file)
+}
+
+// Compile compiles the rules of a File
+func Compile(file *File) (*RuleSet, error) {
+	set := &RuleSet{defaultDecision: file.Default}
+	if set.defaultDecision == "" {
+		set.defaultDecision = Allow
+	}
+	if !valid(set.defaultDecision) {
+		return nil, fmt.Errorf("unknown default decision %q", file.Default)
+	}
+
+	names := make(map[string]bool, len(file.Rules))
+	for _, rule := range file.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule without a name")
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("rule %s: duplicate name", rule.Name)
+		}
+		names[rule.Name] = true
+		if !valid(rule.Decision) {
+			return nil, fmt.Errorf("rule %s: unknown decision %q", rule.Name, rule.Decision)
+		}
+
+		ast, issues := env.Compile(rule.When)
+		if issues.Err() != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("rule %s: expression is %s, not bool", rule.Name, ast.OutputType())
+		}
+		program, err := env.Program(ast, cel.EvalOptions(cel.OptOptimize))
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+
+		rule.DryRun = rule.DryRun || file.DryRun
+		set.rules = append(set.rules, compiled{Rule: rule, program: program})
+	}
+	return set, nil
+}
+
+func valid(decision string) bool {
+	return decision == Allow || decision == Deny || decision == Review
+}
+
+// Len returns the number of rules
+func (s *RuleSet) Len() int {
+	return len(s.rules)
+}
+
+// Decide evaluates the rules in order; the first match that is not a dry
+// run decides. Dry-run rules are evaluated wherever they are listed, so
+// that a rule can be tried out before it is enforced. Rules that fail to
+// evaluate, say because a field they use is missing, do not match; they
+// are returned in errs as *RuleError.
+func (s *RuleSet) Decide(in Input) (decision Decision, errs []error) {
+	vars := map[string]interface{}{
+		"ip":        in.IP,
+		"client_ip": in.ClientIP,
+		"location":  orEmpty(in.Location),
+		"providers": orEmpty(in.Providers),
+		"device":    orEmpty(in.Device),
+	}
+
+	for _, rule := range s.rules {
+		if decision.Decision != "" && !rule.DryRun {
+			continue
+		}
+
+		out, _, err := rule.program.Eval(vars)
+		if err != nil {
+			errs = append(errs, &RuleError{Rule: rule.Name, DryRun: rule.DryRun, Err: err})
+			continue
+		}
+		if matched, _ := out.Value().(bool); !matched {
+			continue
+		}
+
+		match := Match{Rule: rule.Name, Decision: rule.Decision, Explanation: rule.explain()}
+		if rule.DryRun {
+			decision.DryRun = append(decision.DryRun, match)
+			continue
+		}
+		decision.Decision = match.Decision
+		decision.Rule = match.Rule
+		decision.Explanation = match.Explanation
+	}
+
+	if decision.Decision == "" {
+		decision.Decision = s.defaultDecision
+		decision.Explanation = "no rule matched; the default is " + s.defaultDecision
+	}
+	return decision, errs
+}
+
+// explain returns the reason of the rule, or its expression when it gives
+// none
+func (r *Rule) explain() string {
+	if r.Reason != "" {
+		return r.Reason
+	}
+	return "matched " + strings.Join(strings.Fields(r.When), " ")
+}
+
+func orEmpty(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
@@ -0,0 +1,248 @@
+package rules
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, src string) *RuleSet {
+	t.Helper()
+	set, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return set
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"not bool": `
+rules:
+  - {name: a, when: 'ip', decision: deny}`,
+		"syntax": `
+rules:
+  - {name: a, when: 'ip ==', decision: deny}`,
+		"unknown variable": `
+rules:
+  - {name: a, when: 'asn == 1', decision: deny}`,
+		"duplicate name": `
+rules:
+  - {name: a, when: 'true', decision: deny}
+  - {name: a, when: 'false', decision: allow}`,
+		"no name": `
+rules:
+  - {when: 'true', decision: deny}`,
+		"unknown decision": `
+rules:
+  - {name: a, when: 'true', decision: block}`,
+		"unknown default": `
+default: block`,
+		"yaml": `rules: [`,
+	}
+	for name, src := range tests {
+		if _, err := Parse(strings.NewReader(src)); err == nil {
+			t.Errorf("%s: Parse() succeeded", name)
+		}
+	}
+
+	set, err := Parse(strings.NewReader(""))
+	if err != nil || set.Len() != 0 {
+		t.Errorf("empty file: Parse() = %v, %v", set, err)
+	}
+}
+
+func TestDecide(t *testing.T) {
+	set := mustParse(t, `
+default: review
+rules:
+  - name: office
+    when: ip_in(ip, "10.0.0.0/8")
+    decision: allow
+  - name: sanctioned
+    when: has(location.country) && location.country.code in ["KP", "IR"]
+    decision: deny
+    reason: sanctioned country
+  - name: korea
+    when: has(location.country) && location.country.code.startsWith("K")
+    decision: allow
+  - name: bots
+    when: device.bot.category == "scraper"
+    decision: deny
+`)
+
+	country := func(code string) map[string]interface{} {
+		return map[string]interface{}{"country": map[string]interface{}{"code": code}}
+	}
+	tests := []struct {
+		name        string
+		in          Input
+		decision    string
+		rule        string
+		explanation string
+		errors      int
+	}{
+		{
+			// office is listed before sanctioned and wins; the rules after
+			// it are not evaluated
+			name:     "first match",
+			in:       Input{IP: "10.1.2.3", Location: country("KP")},
+			decision: Allow, rule: "office", explanation: `matched ip_in(ip, "10.0.0.0/8")`,
+		},
+		{
+			// sanctioned is listed before korea
+			name:     "order",
+			in:       Input{IP: "175.45.176.1", Location: country("KP")},
+			decision: Deny, rule: "sanctioned", explanation: "sanctioned country",
+		},
+		{
+			name:     "later rule",
+			in:       Input{IP: "1.1.1.1", Location: country("KR")},
+			decision: Allow, rule: "korea",
+			explanation: `matched has(location.country) && location.country.code.startsWith("K")`,
+		},
+		{
+			// bots fails without a device.bot and does not match
+			name:     "default",
+			in:       Input{IP: "8.8.8.8", Location: country("US")},
+			decision: Review, explanation: "no rule matched; the default is review",
+			errors: 1,
+		},
+		{
+			name: "device",
+			in: Input{IP: "8.8.8.8", Device: map[string]interface{}{
+				"bot": map[string]interface{}{"category": "scraper"},
+			}},
+			decision: Deny, rule: "bots", explanation: `matched device.bot.category == "scraper"`,
+		},
+		{
+			name:     "ipv4-mapped",
+			in:       Input{IP: "::ffff:10.0.0.1"},
+			decision: Allow, rule: "office", explanation: `matched ip_in(ip, "10.0.0.0/8")`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := set.Decide(tt.in)
+			if got.Decision != tt.decision || got.Rule != tt.rule || got.Explanation != tt.explanation {
+				t.Errorf("Decide() = %+v, want %s by %q: %s", got, tt.decision, tt.rule, tt.explanation)
+			}
+			if len(errs) != tt.errors {
+				t.Errorf("Decide() errors = %v, want %d", errs, tt.errors)
+			}
+		})
+	}
+}
+
+func TestDecideDryRun(t *testing.T) {
+	set := mustParse(t, `
+rules:
+  - name: trial-before
+    when: 'true'
+    decision: review
+    reason: would review
+    dry_run: true
+  - name: enforced
+    when: ip == "192.0.2.1"
+    decision: deny
+  - name: trial-after
+    when: 'true'
+    decision: deny
+    dry_run: true
+  - name: never
+    when: 'true'
+    decision: allow
+`)
+
+	got, errs := set.Decide(Input{IP: "192.0.2.1"})
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if got.Decision != Deny || got.Rule != "enforced" {
+		t.Errorf("Decide() = %+v, want deny by enforced", got)
+	}
+	// Dry-run rules after the deciding rule are still evaluated; enforced
+	// rules after it are not
+	want := []Match{
+		{Rule: "trial-before", Decision: Review, Explanation: "would review"},
+		{Rule: "trial-after", Decision: Deny, Explanation: "matched true"},
+	}
+	if len(got.DryRun) != len(want) {
+		t.Fatalf("DryRun = %+v, want %+v", got.DryRun, want)
+	}
+	for i := range want {
+		if got.DryRun[i] != want[i] {
+			t.Errorf("DryRun[%d] = %+v, want %+v", i, got.DryRun[i], want[i])
+		}
+	}
+}
+
+func TestFileDryRun(t *testing.T) {
+	set := mustParse(t, `
+default: deny
+dry_run: true
+rules:
+  - {name: a, when: 'true', decision: allow}
+`)
+	got, _ := set.Decide(Input{})
+	if got.Decision != Deny || got.Rule != "" || len(got.DryRun) != 1 {
+		t.Errorf("Decide() = %+v, want the default with one dry-run match", got)
+	}
+}
+
+func TestInvalidCIDR(t *testing.T) {
+	set := mustParse(t, `
+rules:
+  - {name: a, when: 'ip_in(ip, "10.0.0.0/33")', decision: deny}
+`)
+	got, errs := set.Decide(Input{IP: "10.0.0.1"})
+	if got.Decision != Allow || len(errs) != 1 {
+		t.Fatalf("Decide() = %+v, %v; want allow with an error", got, errs)
+	}
+	var ruleErr *RuleError
+	if !errors.As(errs[0], &ruleErr) || ruleErr.Rule != "a" || ruleErr.DryRun {
+		t.Errorf("Decide() error = %#v, want a *RuleError for a", errs[0])
+	}
+}
+
+// TestExampleFile checks that rules.example.yaml compiles and decides the
+// cases it documents over /v2 JSON values, where numbers are doubles
+func TestExampleFile(t *testing.T) {
+	f, err := os.Open("../rules.example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	set, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	location := func(code string, asn float64, anonymous bool) map[string]interface{} {
+		return map[string]interface{}{
+			"country":    map[string]interface{}{"code": code, "gdpr": false},
+			"asn":        map[string]interface{}{"number": asn, "organization": "Example"},
+			"anonymizer": map[string]interface{}{"anonymous": anonymous, "vpn": anonymous},
+		}
+	}
+	tests := []struct {
+		name     string
+		in       Input
+		decision string
+		rule     string
+	}{
+		{"office", Input{IP: "10.0.0.1", Location: location("KP", 64500, true)}, Allow, "office"},
+		{"sanctioned", Input{IP: "175.45.176.1", Location: location("KP", 13335, false)}, Deny, "sanctioned-countries"},
+		{"partner", Input{IP: "104.16.0.1", Location: location("US", 13335, true)}, Allow, "partner-networks"},
+		{"proxy", Input{IP: "198.51.100.1", Location: location("US", 64500, true)}, Review, "anonymous-proxies"},
+		{"clean", Input{IP: "198.51.100.1", Location: location("US", 64500, false)}, Allow, ""},
+		{"no databases", Input{IP: "198.51.100.1", Location: map[string]interface{}{}}, Allow, ""},
+	}
+	for _, tt := range tests {
+		got, _ := set.Decide(tt.in)
+		if got.Decision != tt.decision || got.Rule != tt.rule {
+			t.Errorf("%s: Decide() = %s by %q, want %s by %q", tt.name, got.Decision, got.Rule, tt.decision, tt.rule)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requestTimeoutHeader carries how long the caller is prepared to wait, as
+// a duration such as 850ms. The Go client sends what is left of its
+// context's deadline.
+const requestTimeoutHeader = "X-Request-Timeout"
+
+// requestTimeout bounds a request by its X-Request-Timeout header. The
+// handlers' lookups and DNS queries run under c.UserContext(), so they are
+// abandoned once the timeout passes, and the request then fails with 504
+// Gateway Timeout rather than returning partial results. It follows the
+// version middleware so that errors take the route's version.
+func requestTimeout(c *fiber.Ctx) error {
+	return withRequestTimeout(c, writeError)
+}
+
+// rpcRequestTimeout is requestTimeout for the transcoded /v1/rpc routes,
+// which report failures as google.rpc.Status objects
+func rpcRequestTimeout(c *fiber.Ctx) error {
+	return withRequestTimeout(c, func(c *fiber.Ctx, code int, message string) error {
+		if code == fiber.StatusGatewayTimeout {
+			return writeStatus(c, status.New(codes.DeadlineExceeded, message))
+		}
+		return writeStatus(c, status.New(codes.InvalidArgument, message))
+	})
+}
+
+// withRequestTimeout runs the rest of the chain under the X-Request-Timeout
+// deadline, reporting failures with fail
+func withRequestTimeout(c *fiber.Ctx, fail func(c *fiber.Ctx, code int, message string) error) error {
+	value := c.Get(requestTimeoutHeader)
+	if value == "" {
+		return c.Next()
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return fail(c, fiber.StatusBadRequest, "invalid "+requestTimeoutHeader+" header")
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+	defer cancel()
+	c.SetUserContext(ctx)
+
+	if err := c.Next(); err != nil {
+		return err
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fail(c, fiber.StatusGatewayTimeout, "request timed out")
+	}
+	return nil
+}
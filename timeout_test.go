@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	app := fiber.New()
+	app.Get("/v2/lookup", useVersion(apiV2), requestTimeout, func(c *fiber.Ctx) error {
+		deadline, ok := c.UserContext().Deadline()
+		if !ok {
+			return c.SendString("unbounded")
+		}
+		if c.Query("wait") != "" {
+			<-c.UserContext().Done()
+		}
+		return c.SendString(time.Until(deadline).Round(time.Second).String())
+	})
+
+	tests := []struct {
+		name, timeout, query string
+		status               int
+		body                 string
+	}{
+		{name: "no header", status: fiber.StatusOK, body: "unbounded"},
+		{name: "deadline", timeout: "5s", status: fiber.StatusOK, body: "5s"},
+		{name: "timed out", timeout: "20ms", query: "?wait=1", status: fiber.StatusGatewayTimeout, body: `"code":"timeout"`},
+		{name: "invalid", timeout: "soon", status: fiber.StatusBadRequest, body: `"code":"invalid_request"`},
+		{name: "negative", timeout: "-1s", status: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/v2/lookup"+tt.query, nil)
+			if tt.timeout != "" {
+				req.Header.Set(requestTimeoutHeader, tt.timeout)
+			}
+			res, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status || !strings.Contains(string(body), tt.body) {
+				t.Errorf("got %d %s, want %d with %q", res.StatusCode, body, tt.status, tt.body)
+			}
+		})
+	}
+}
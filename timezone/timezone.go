@@ -0,0 +1,97 @@
+package timezone
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ringsaturn/tzf"
+
+	// Embed the zone database so that zones load on hosts without one
+	_ "time/tzdata"
+)
+
+// Finder maps coordinates to IANA time zones using the time-zone boundary
+// polygons embedded in tzf
+type Finder struct {
+	finder tzf.F
+}
+
+// NewFinder loads the embedded boundaries, which takes a moment and should
+// be done once at startup
+func NewFinder() (*Finder, error) {
+	finder, err := tzf.NewDefaultFinder()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load time zone boundaries: %w", err)
+	}
+	return &Finder{finder: finder}, nil
+}
+
+// Lookup returns the time zone at the coordinates. Points at sea get the
+// nautical Etc/GMT zones.
+func (f *Finder) Lookup(latitude, longitude float64) string {
+	return f.finder.GetTimezoneName(longitude, latitude)
+}
+
+// Info is the state of a time zone at some instant
+type Info struct {
+	Name string
+	// Offset is the offset from UTC, including daylight saving time
+	Offset time.Duration
+	DST    bool
+	// LocalTime is the instant in the zone
+	LocalTime time.Time
+}
+
+// FormatOffset formats the offset as ±hh:mm
+func (i Info) FormatOffset() string {
+	return i.LocalTime.Format("-07:00")
+}
+
+var locations sync.Map
+
+// Load returns the location named name, caching it since time.LoadLocation
+// parses the zone data on every call
+func Load(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// Valid reports whether name is in the IANA zone database, as "UTC" and
+// "Etc/GMT+5" are. Providers put offsets such as "+05:30" or placeholder
+// text in their time zone fields, which are rejected, as are the empty name
+// and "Local", which time.LoadLocation accepts but that name no zone.
+func Valid(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := Load(name)
+	return err == nil
+}
+
+// At returns the state of the zone named name at t; name must be Valid
+func At(name string, t time.Time) (Info, error) {
+	if !Valid(name) {
+		return Info{}, fmt.Errorf("unknown time zone %q", name)
+	}
+	loc, err := Load(name)
+	if err != nil {
+		return Info{}, err
+	}
+
+	local := t.In(loc)
+	_, offset := local.Zone()
+	return Info{
+		Name:      name,
+		Offset:    time.Duration(offset) * time.Second,
+		DST:       local.IsDST(),
+		LocalTime: local,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package timezone
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValid(t *testing.T) {
+	tests := map[string]bool{
+		"America/New_York": true,
+		"Asia/Kolkata":     true,
+		"UTC":              true,
+		"Etc/UTC":          true,
+		"Etc/GMT+5":        true,
+		"":                 false,
+		"Local":            false,
+		"-":                false,
+		"+05:30":           false,
+		"UTC+05:30":        false,
+		"America/Nowhere":  false,
+		"../etc/passwd":    false,
+		"america/new_york": false,
+	}
+	for name, want := range tests {
+		if got := Valid(name); got != want {
+			t.Errorf("Valid(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestAt(t *testing.T) {
+	summer := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+	winter := time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		t      time.Time
+		offset string
+		dst    bool
+		local  string
+	}{
+		{"America/New_York", summer, "-04:00", true, "2024-07-01T08:00:00-04:00"},
+		{"America/New_York", winter, "-05:00", false, "2024-01-15T07:00:00-05:00"},
+		{"Asia/Kolkata", summer, "+05:30", false, "2024-07-01T17:30:00+05:30"},
+		{"Australia/Sydney", winter, "+11:00", true, "2024-01-15T23:00:00+11:00"},
+		{"UTC", summer, "+00:00", false, "2024-07-01T12:00:00Z"},
+		{"Etc/GMT+5", summer, "-05:00", false, "2024-07-01T07:00:00-05:00"},
+	}
+	for _, tt := range tests {
+		info, err := At(tt.name, tt.t)
+		if err != nil {
+			t.Errorf("At(%s) = %v", tt.name, err)
+			continue
+		}
+		if info.Name != tt.name || info.FormatOffset() != tt.offset || info.DST != tt.dst ||
+			info.LocalTime.Format(time.RFC3339) != tt.local {
+			t.Errorf("At(%s, %v) = %s %s DST %v at %s, want %s DST %v at %s", tt.name, tt.t,
+				info.Name, info.FormatOffset(), info.DST, info.LocalTime.Format(time.RFC3339), tt.offset, tt.dst, tt.local)
+		}
+	}
+
+	for _, name := range []string{"", "Local", "+05:30"} {
+		if _, err := At(name, summer); err == nil {
+			t.Errorf("At(%q) succeeded", name)
+		}
+	}
+}
+
+func TestFinderLookup(t *testing.T) {
+	finder, err := NewFinder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		latitude, longitude float64
+		want                string
+	}{
+		{40.7128, -74.0060, "America/New_York"},
+		{48.8566, 2.3522, "Europe/Paris"},
+		{28.6139, 77.2090, "Asia/Kolkata"},
+		{-33.8688, 151.2093, "Australia/Sydney"},
+		// At sea
+		{0, -30, "Etc/GMT+2"},
+	}
+	for _, tt := range tests {
+		if got := finder.Lookup(tt.latitude, tt.longitude); got != tt.want {
+			t.Errorf("Lookup(%v, %v) = %q, want %q", tt.latitude, tt.longitude, got, tt.want)
+		}
+		if got := finder.Lookup(tt.latitude, tt.longitude); !Valid(got) {
+			t.Errorf("Lookup(%v, %v) = %q, which is not Valid", tt.latitude, tt.longitude, got)
+		}
+	}
+}
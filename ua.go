@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	pb "github.com/imnitish-dev/ip2location/proto"
+	"github.com/imnitish-dev/ip2location/useragent"
+)
+
+// maxUABatch caps the number of user agents parsed per POST /ua request
+const maxUABatch = 1000
+
+// UAQuery is a user agent to parse, with optional client hints keyed by
+// header name
+type UAQuery struct {
+	UserAgent string            `json:"userAgent"`
+	Hints     map[string]string `json:"hints,omitempty"`
+}
+
+// UAResult is the parsed form of a UAQuery
+type UAResult struct {
+	UserAgent     string     `json:"userAgent"`
+	DeviceBrowser DeviceInfo `json:"deviceBrowser"`
+}
+
+// hintsFromMap builds client hints from a map with case-insensitive keys
+func hintsFromMap(hints map[string]string) useragent.ClientHints {
+	if len(hints) == 0 {
+		return useragent.ClientHints{}
+	}
+	lower := make(map[string]string, len(hints))
+	for name, value := range hints {
+		lower[strings.ToLower(name)] = value
+	}
+	return useragent.HintsFromHeaders(func(name string) string {
+		return lower[strings.ToLower(name)]
+	})
+}
+
+// handleParseUA parses the ua query parameter, or the caller's own
+// User-Agent when it is absent, using the request's client hint headers
+func (a *App) handleParseUA(c *fiber.Ctx) error {
+	ua := c.Query("ua")
+	if ua == "" {
+		ua = c.Get(fiber.HeaderUserAgent)
+	}
+
+	return uaJSON(c, UAResult{
+		UserAgent:     ua,
+		DeviceBrowser: a.ua.ParseWithHints(ua, clientHints(c)),
+	})
+}
+
+// uaJSON writes a UAResult or a []UAResult in the version of the route
+func uaJSON(c *fiber.Ctx, v interface{}) error {
+	if versionOf(c) == apiV2 {
+		v = toV2Value(v, c.Response().StatusCode())
+	}
+	return c.JSON(v)
+}
+
+// decodeUAQueries decodes a UAQuery or an array of them, with the /v2
+// field names on /v2 routes. single reports whether body was an object.
+func decodeUAQueries(c *fiber.Ctx, body []byte) (queries []UAQuery, single bool, err error) {
+	decode := c.App().Config().JSONDecoder
+	single = len(body) == 0 || body[0] != '['
+
+	if versionOf(c) == apiV2 {
+		var v2 []UAQueryV2
+		if single {
+			v2 = make([]UAQueryV2, 1)
+			err = decode(body, &v2[0])
+		} else {
+			err = decode(body, &v2)
+		}
+		for _, query := range v2 {
+			queries = append(queries, UAQuery{UserAgent: query.UserAgent, Hints: query.Hints})
+		}
+		return queries, single, err
+	}
+
+	if single {
+		queries = make([]UAQuery, 1)
+		err = decode(body, &queries[0])
+	} else {
+		err = decode(body, &queries)
+	}
+	return queries, single, err
+}
+
+// handleParseUABatch parses a single UAQuery object or an array of them.
+// A single query without hints falls back to the request's hint headers.
+func (a *App) handleParseUABatch(c *fiber.Ctx) error {
+	queries, single, err := decodeUAQueries(c, bytes.TrimSpace(c.Body()))
+	if err != nil {
+		return writeError(c, fiber.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+
+	if single {
+		hints := hintsFromMap(queries[0].Hints)
+		if hints.IsZero() {
+			hints = clientHints(c)
+		}
+		return uaJSON(c, UAResult{
+			UserAgent:     queries[0].UserAgent,
+			DeviceBrowser: a.ua.ParseWithHints(queries[0].UserAgent, hints),
+		})
+	}
+
+	if len(queries) > maxUABatch {
+		return writeError(c, fiber.StatusBadRequest, fmt.Sprintf("too many user agents: at most %d per request", maxUABatch))
+	}
+
+	results := make([]UAResult, len(queries))
+	for i, query := range queries {
+		results[i] = UAResult{
+			UserAgent:     query.UserAgent,
+			DeviceBrowser: a.ua.ParseWithHints(query.UserAgent, hintsFromMap(query.Hints)),
+		}
+	}
+	return uaJSON(c, results)
+}
+
+// ParseUserAgent implements the gRPC user-agent parsing method
+func (s *GRPCServer) ParseUserAgent(ctx context.Context, req *pb.ParseUserAgentRequest) (*pb.ParseUserAgentResponse, error) {
+	response := &pb.ParseUserAgentResponse{}
+
+	hints := hintsFromMap(req.Hints)
+	if req.UserAgent == "" && hints.IsZero() {
+		response.Message = "user_agent is required"
+		return response, nil
+	}
+
+	info := s.app.ua.ParseWithHints(req.UserAgent, hints)
+	response.Device = toPBDeviceInfo(&info)
+	return response, nil
+}
+
+// toPBDeviceInfo converts parsed device information to its protobuf form
+func toPBDeviceInfo(info *DeviceInfo) *pb.DeviceInfo {
+	return &pb.DeviceInfo{
+		Os:             info.OS,
+		Platform:       info.Platform,
+		Version:        value(info.Version),
+		Browser:        value(info.Browser),
+		BrowserVersion: value(info.BrowserVersion),
+		Engine:         value(info.Engine),
+		EngineVersion:  value(info.EngineVersion),
+		Architecture:   value(info.Architecture),
+		App:            value(info.App),
+		AppVersion:     value(info.AppVersion),
+		Client:         value(info.Client),
+		ClientVersion:  value(info.ClientVersion),
+		DeviceVendor:   value(info.DeviceVendor),
+		DeviceModel:    value(info.DeviceModel),
+		DeviceType:     value(info.DeviceType),
+		IsBot:          info.IsBot,
+		BotName:        value(info.BotName),
+		BotCategory:    value(info.BotCategory),
+		BotVerified:    info.BotVerified,
+	}
+}
+
+// value dereferences an optional string, mapping nil to ""
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
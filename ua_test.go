@@ -0,0 +1,142 @@
+package main
+
+import (
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/imnitish-dev/ip2location/useragent"
+)
+
+const (
+	testChromeUA  = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	testIPhoneUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1"
+	testGooglebot = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+)
+
+func newUAApp() *fiber.App {
+	a := &App{ua: useragent.NewDefault(0)}
+	app := fiber.New()
+	for _, version := range []*apiVersion{apiV1, apiV2} {
+		app.Get("/"+version.name+"/ua", useVersion(version), a.handleParseUA)
+		app.Post("/"+version.name+"/ua", useVersion(version), a.handleParseUABatch)
+	}
+	return app
+}
+
+// sendUA sends a request to app and returns the status and body
+func sendUA(t *testing.T, app *fiber.App, method, target, body string, header map[string]string) (int, []byte) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, data
+}
+
+func TestParseUA(t *testing.T) {
+	app := newUAApp()
+
+	decode := func(data []byte) UAResult {
+		t.Helper()
+		var result UAResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("%v: %s", err, data)
+		}
+		return result
+	}
+
+	t.Run("query", func(t *testing.T) {
+		// The ua parameter wins over the caller's own User-Agent
+		status, data := sendUA(t, app, "GET", "/v1/ua?ua="+url.QueryEscape(testIPhoneUA), "",
+			map[string]string{fiber.HeaderUserAgent: testChromeUA})
+		result := decode(data)
+		if status != fiber.StatusOK || result.UserAgent != testIPhoneUA || result.DeviceBrowser.OS != "iOS" ||
+			value(result.DeviceBrowser.DeviceModel) != "iPhone" {
+			t.Errorf("got %d %s", status, data)
+		}
+	})
+
+	t.Run("own user agent and hints", func(t *testing.T) {
+		status, data := sendUA(t, app, "GET", "/v1/ua", "", map[string]string{
+			fiber.HeaderUserAgent:                  testChromeUA,
+			useragent.HeaderSecCHUA:                `"Chromium";v="120", "Microsoft Edge";v="120"`,
+			useragent.HeaderSecCHUAPlatform:        `"Windows"`,
+			useragent.HeaderSecCHUAPlatformVersion: `"15.0.0"`,
+		})
+		result := decode(data)
+		if status != fiber.StatusOK || result.UserAgent != testChromeUA || value(result.DeviceBrowser.Browser) != "Edge" ||
+			value(result.DeviceBrowser.Version) != "11" {
+			t.Errorf("got %d %s", status, data)
+		}
+	})
+
+	t.Run("single", func(t *testing.T) {
+		body := `{"userAgent": "` + testChromeUA + `", "hints": {"sec-ch-ua-platform-version": "\"15.0.0\"", "Sec-CH-UA-Platform": "\"Windows\""}}`
+		status, data := sendUA(t, app, "POST", "/v1/ua", body, nil)
+		if result := decode(data); status != fiber.StatusOK || value(result.DeviceBrowser.Version) != "11" {
+			t.Errorf("got %d %s", status, data)
+		}
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		body := `[{"userAgent": "` + testChromeUA + `"}, {"userAgent": "` + testGooglebot + `"}, {"userAgent": ""}]`
+		status, data := sendUA(t, app, "POST", "/v1/ua", body, nil)
+		var results []UAResult
+		if err := json.Unmarshal(data, &results); err != nil || status != fiber.StatusOK || len(results) != 3 {
+			t.Fatalf("got %d %s", status, data)
+		}
+		if value(results[0].DeviceBrowser.Browser) != "Chrome" || results[0].DeviceBrowser.IsBot ||
+			!results[1].DeviceBrowser.IsBot || value(results[1].DeviceBrowser.BotName) != "Googlebot" ||
+			results[2].DeviceBrowser.OS != "Unknown" {
+			t.Errorf("got %s", data)
+		}
+	})
+
+	t.Run("batch v2", func(t *testing.T) {
+		body := `[{"user_agent": "` + testGooglebot + `"}]`
+		status, data := sendUA(t, app, "POST", "/v2/ua", body, nil)
+		var results []UAResultV2
+		if err := json.Unmarshal(data, &results); err != nil || status != fiber.StatusOK || len(results) != 1 ||
+			results[0].UserAgent != testGooglebot || results[0].Device == nil || results[0].Device.Bot == nil {
+			t.Errorf("got %d %s", status, data)
+		}
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		body := "[" + strings.TrimSuffix(strings.Repeat(`{"userAgent": "curl/8.4.0"},`, maxUABatch+1), ",") + "]"
+		status, data := sendUA(t, app, "POST", "/v1/ua", body, nil)
+		if status != fiber.StatusBadRequest || !strings.Contains(string(data), "too many user agents") {
+			t.Errorf("got %d %s", status, data)
+		}
+
+		status, data = sendUA(t, app, "POST", "/v2/ua", strings.ReplaceAll(body, "userAgent", "user_agent"), nil)
+		if status != fiber.StatusBadRequest || !strings.Contains(string(data), `"code":"invalid_request"`) {
+			t.Errorf("v2 got %d %s", status, data)
+		}
+	})
+
+	for _, body := range []string{`{"userAgent": `, `[1, 2]`, `{"userAgent": 5}`} {
+		t.Run("malformed "+body, func(t *testing.T) {
+			status, data := sendUA(t, app, "POST", "/v1/ua", body, nil)
+			if status != fiber.StatusBadRequest || !strings.Contains(string(data), "invalid request body") {
+				t.Errorf("got %d %s", status, data)
+			}
+		})
+	}
+}
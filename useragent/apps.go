@@ -0,0 +1,71 @@
+package useragent
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// notApps are leading product tokens that never name a native app
+var notApps = map[string]bool{
+	"mozilla": true,
+	"opera":   true,
+	"dalvik":  true,
+}
+
+// matchApp reports the app name and version from the leading product token,
+// e.g. "MyApp/5.1 (iPhone; iOS 17.2)". Tokens that belong to a browser or to
+// the HTTP client library itself are skipped.
+func (p *Parser) matchApp(ua string, info *DeviceInfo) {
+	fields, ok := match(p.app, ua)
+	if !ok {
+		return
+	}
+
+	name := fields[0]
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	if notApps[strings.ToLower(name)] || (info.Client != nil && strings.EqualFold(*info.Client, name)) {
+		return
+	}
+
+	info.App = ptr(name)
+	info.AppVersion = ptr(joinVersion(fields[1:]))
+}
+
+// darwinOS maps the Darwin kernel version that CFNetwork clients report to
+// the iOS or macOS release it shipped with. macOS clients append the CPU
+// architecture, iOS clients do not.
+func darwinOS(ua string, fields [4]string, info *DeviceInfo) {
+	major, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return
+	}
+	minor := fields[2]
+	if minor == "" {
+		minor = "0"
+	}
+
+	macOS := strings.Contains(ua, "(x86_64)") || strings.Contains(ua, "(arm64)") ||
+		strings.Contains(ua, "Macintosh")
+
+	var version string
+	switch {
+	// Apple moved both to year-based numbering with Darwin 25
+	case major >= 25:
+		version = strconv.Itoa(major+1) + "." + minor
+	case macOS && major >= 20:
+		version = strconv.Itoa(major-9) + "." + minor
+	case macOS && major >= 5:
+		version = "10." + strconv.Itoa(major-4)
+	case !macOS && major >= 10:
+		version = strconv.Itoa(major-6) + "." + minor
+	}
+
+	info.OS = "iOS"
+	if macOS {
+		info.OS = "MacOS"
+	}
+	info.Version = ptr(version)
+}
@@ -0,0 +1,285 @@
+package useragent
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"time"
+)
+
+// Bot categories
+const (
+	BotSearchEngine = "search_engine"
+	BotAICrawler    = "ai_crawler"
+	BotMonitor      = "monitor"
+	BotScraper      = "scraper"
+	BotLibrary      = "library"
+)
+
+// BotRule is a bot signature. Name may reference capture groups as $1..$9
+// and defaults to the first group. Domains lists the reverse-DNS suffixes
+// the operator publishes for verification.
+type BotRule struct {
+	Regex     string   `yaml:"regex"`
+	RegexFlag string   `yaml:"regex_flag"`
+	Name      string   `yaml:"name"`
+	Category  string   `yaml:"category"`
+	Domains   []string `yaml:"domains"`
+}
+
+type compiledBot struct {
+	re *regexp.Regexp
+	// Every match contains one of literals, compared ignoring case when
+	// fold is set. Most user agents are not bots, and checking for the
+	// literals rejects them far faster than running the regexes.
+	literals []string
+	fold     bool
+	name     string
+	category string
+	domains  []string
+}
+
+func compileBots(bots []BotRule) ([]compiledBot, error) {
+	compiled := make([]compiledBot, 0, len(bots))
+	for _, bot := range bots {
+		re, err := compileRegex(bot.Regex, bot.RegexFlag)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := syntax.Parse(re.String(), syntax.Perl)
+		if err != nil {
+			return nil, err
+		}
+		literals, fold := requiredLiterals(parsed)
+		compiled = append(compiled, compiledBot{
+			re:       re,
+			literals: literals,
+			fold:     fold,
+			name:     bot.Name,
+			category: bot.Category,
+			domains:  bot.Domains,
+		})
+	}
+	return compiled, nil
+}
+
+// requiredLiterals returns strings one of which every match of re
+// contains, or nil when it cannot tell. Case-insensitive literals are
+// returned in lower case with fold set.
+func requiredLiterals(re *syntax.Regexp) (literals []string, fold bool) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return []string{strings.ToLower(string(re.Rune))}, true
+		}
+		return []string{string(re.Rune)}, false
+
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+
+	case syntax.OpConcat:
+		// Every part must match, so the part with the longest literals
+		// is the most selective
+		for _, sub := range re.Sub {
+			if l, f := requiredLiterals(sub); l != nil && (literals == nil || shortest(l) > shortest(literals)) {
+				literals, fold = l, f
+			}
+		}
+		return literals, fold
+
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			l, f := requiredLiterals(sub)
+			if l == nil {
+				return nil, false
+			}
+			literals = append(literals, l...)
+			fold = fold || f
+		}
+		if fold {
+			for i := range literals {
+				literals[i] = strings.ToLower(literals[i])
+			}
+		}
+		return literals, fold
+	}
+	return nil, false
+}
+
+func shortest(literals []string) int {
+	n := len(literals[0])
+	for _, literal := range literals[1:] {
+		n = min(n, len(literal))
+	}
+	return n
+}
+
+// mayMatch reports whether ua contains one of the bot's literals, and so
+// whether its regex can match
+func (b *compiledBot) mayMatch(ua string) bool {
+	if b.literals == nil {
+		return true
+	}
+	for _, literal := range b.literals {
+		if b.fold && containsFold(ua, literal) || !b.fold && strings.Contains(ua, literal) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether s contains the lower-case ASCII literal,
+// ignoring case
+func containsFold(s, literal string) bool {
+	for i := 0; i+len(literal) <= len(s); i++ {
+		j := 0
+		for j < len(literal) && lower(s[i+j]) == literal[j] {
+			j++
+		}
+		if j == len(literal) {
+			return true
+		}
+	}
+	return false
+}
+
+func lower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// matchBot fills in the bot fields of info from the first matching signature
+func matchBot(bots []compiledBot, ua string, info *DeviceInfo) {
+	for i := range bots {
+		bot := &bots[i]
+		if !bot.mayMatch(ua) {
+			continue
+		}
+		loc := bot.re.FindStringSubmatchIndex(ua)
+		if loc == nil {
+			continue
+		}
+
+		name := group(ua, loc, 1)
+		if bot.name != "" {
+			name = expand(bot.name, ua, loc)
+		}
+
+		info.IsBot = true
+		info.BotName = ptr(name)
+		info.BotCategory = ptr(bot.category)
+		info.botDomains = bot.domains
+		return
+	}
+}
+
+// BotVerifier checks that a client claiming to be a known search-engine bot
+// really is, using forward-confirmed reverse DNS: the IP's PTR name must sit
+// under one of the bot's published domains and resolve back to the same IP.
+// Definitive results are cached per bot and IP; lookups that fail with a
+// timeout or server error count as unverified but are tried again next time.
+type BotVerifier struct {
+	resolver *net.Resolver
+	timeout  time.Duration
+	cache    *lru[bool]
+}
+
+// NewBotVerifier creates a verifier. A nil resolver uses net.DefaultResolver.
+func NewBotVerifier(resolver *net.Resolver, timeout time.Duration) *BotVerifier {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &BotVerifier{
+		resolver: resolver,
+		timeout:  timeout,
+		cache:    newLRU[bool](DefaultCacheSize),
+	}
+}
+
+// Verify sets info.BotVerified for bots that publish verification domains
+// and returns the updated copy. Other user agents are returned unchanged.
+func (v *BotVerifier) Verify(ctx context.Context, info DeviceInfo, ip string) DeviceInfo {
+	if !info.IsBot || info.BotName == nil || len(info.botDomains) == 0 {
+		return info
+	}
+
+	key := *info.BotName + "|" + ip
+	verified, ok := v.cache.get(key)
+	if !ok {
+		var definitive bool
+		verified, definitive = v.verify(ctx, ip, info.botDomains)
+		if definitive {
+			v.cache.add(key, verified)
+		}
+	}
+
+	info.BotVerified = &verified
+	return info
+}
+
+// verify reports whether ip belongs to one of domains, and whether the
+// answer is definitive: a transient DNS failure is not proof of an impostor
+func (v *BotVerifier) verify(ctx context.Context, ip string, domains []string) (verified, definitive bool) {
+	if v.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, v.timeout)
+		defer cancel()
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false, true
+	}
+
+	names, err := v.resolver.LookupAddr(ctx, ip)
+	if err != nil {
+		return false, isNotFound(err)
+	}
+
+	definitive = true
+	for _, name := range names {
+		name = strings.TrimSuffix(strings.ToLower(name), ".")
+		if !underDomain(name, domains) {
+			continue
+		}
+
+		addrs, err := v.resolver.LookupIPAddr(ctx, name)
+		if err != nil {
+			definitive = definitive && isNotFound(err)
+			continue
+		}
+		for _, a := range addrs {
+			if a.IP.Equal(addr) {
+				return true, true
+			}
+		}
+	}
+	return false, definitive
+}
+
+// isNotFound reports whether a lookup failed because the name has no
+// records, as opposed to a timeout or server failure
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// underDomain reports whether name equals or is a subdomain of one of domains
+func underDomain(name string, domains []string) bool {
+	for _, domain := range domains {
+		if name == domain || strings.HasSuffix(name, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
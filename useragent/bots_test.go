@@ -0,0 +1,205 @@
+package useragent
+
+import (
+	"context"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imnitish-dev/ip2location/internal/dnstest"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestBotVerifier(t *testing.T) {
+	stub := dnstest.Start(t, &dnstest.Server{Zone: map[string][]dnsmessage.Resource{
+		"1.66.249.66.in-addr.arpa.": {dnstest.PTR("1.66.249.66.in-addr.arpa.", "crawl-66-249-66-1.googlebot.com.", 60)},
+		"9.113.0.203.in-addr.arpa.": {dnstest.PTR("9.113.0.203.in-addr.arpa.", "crawl.attacker.example.", 60)},
+		// Claims a Google name that does not resolve back
+		"10.113.0.203.in-addr.arpa.":       {dnstest.PTR("10.113.0.203.in-addr.arpa.", "spoofed.googlebot.com.", 60)},
+		"crawl-66-249-66-1.googlebot.com.": {dnstest.A("crawl-66-249-66-1.googlebot.com.", "66.249.66.1", 60)},
+		"spoofed.googlebot.com.":           {dnstest.A("spoofed.googlebot.com.", "66.249.66.200", 60)},
+	}})
+	verifier := NewBotVerifier(stub.Resolver(), time.Second)
+	bot := NewDefault(0).Parse(googlebot)
+
+	verify := func(ip string) bool {
+		t.Helper()
+		info := verifier.Verify(context.Background(), bot, ip)
+		if info.BotVerified == nil {
+			t.Fatalf("Verify(%s) left BotVerified unset", ip)
+		}
+		return *info.BotVerified
+	}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "66.249.66.1", want: true},
+		{ip: "203.0.113.9", want: false},
+		{ip: "203.0.113.10", want: false},
+		{ip: "198.51.100.1", want: false}, // no PTR record
+	}
+	for _, tt := range tests {
+		if got := verify(tt.ip); got != tt.want {
+			t.Errorf("Verify(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+
+		// Definitive answers are cached
+		before := stub.Queries.Load()
+		if got := verify(tt.ip); got != tt.want {
+			t.Errorf("cached Verify(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+		if after := stub.Queries.Load(); after != before {
+			t.Errorf("cached Verify(%s) sent %d queries", tt.ip, after-before)
+		}
+	}
+
+	if info := verifier.Verify(context.Background(), NewDefault(0).Parse(chromeWindows), "66.249.66.1"); info.BotVerified != nil {
+		t.Error("Verify() checked a browser")
+	}
+}
+
+func TestBotVerifierRejectsCloudHosts(t *testing.T) {
+	// A Google Cloud VM with a forward-confirmed name, spoofing AdsBot
+	stub := dnstest.Start(t, &dnstest.Server{Zone: map[string][]dnsmessage.Resource{
+		"4.3.2.35.in-addr.arpa.":             {dnstest.PTR("4.3.2.35.in-addr.arpa.", "4.3.2.35.bc.googleusercontent.com.", 60)},
+		"4.3.2.35.bc.googleusercontent.com.": {dnstest.A("4.3.2.35.bc.googleusercontent.com.", "35.2.3.4", 60)},
+	}})
+	verifier := NewBotVerifier(stub.Resolver(), time.Second)
+	bot := NewDefault(0).Parse("AdsBot-Google (+http://www.google.com/adsbot.html)")
+	if !bot.IsBot || bot.BotName == nil || *bot.BotName != "AdsBot-Google" {
+		t.Fatalf("Parse() = %+v, want AdsBot-Google", bot)
+	}
+
+	if info := verifier.Verify(context.Background(), bot, "35.2.3.4"); info.BotVerified == nil || *info.BotVerified {
+		t.Error("Verify() accepted a bc.googleusercontent.com host")
+	}
+}
+
+func TestBotVerifierRetriesTransientFailures(t *testing.T) {
+	stub := dnstest.Start(t, &dnstest.Server{Zone: map[string][]dnsmessage.Resource{
+		"1.66.249.66.in-addr.arpa.":        {dnstest.PTR("1.66.249.66.in-addr.arpa.", "crawl-66-249-66-1.googlebot.com.", 60)},
+		"crawl-66-249-66-1.googlebot.com.": {dnstest.A("crawl-66-249-66-1.googlebot.com.", "66.249.66.1", 60)},
+	}})
+	verifier := NewBotVerifier(stub.Resolver(), time.Second)
+	bot := NewDefault(0).Parse(googlebot)
+
+	stub.Failing.Store(true)
+	if info := verifier.Verify(context.Background(), bot, "66.249.66.1"); *info.BotVerified {
+		t.Fatal("Verify() succeeded while DNS was failing")
+	}
+
+	stub.Failing.Store(false)
+	if info := verifier.Verify(context.Background(), bot, "66.249.66.1"); !*info.BotVerified {
+		t.Error("a SERVFAIL was cached as a failed verification")
+	}
+}
+
+func TestMatchBot(t *testing.T) {
+	bots := NewDefault(0).bots
+	tests := []struct {
+		ua, name, category string
+	}{
+		{googlebot, "Googlebot", BotSearchEngine},
+		{"Mozilla/5.0 (compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm)", "Bingbot", BotSearchEngine},
+		// The Bing signature ignores case
+		{"Mozilla/5.0 (compatible; BINGBOT/2.0)", "Bingbot", BotSearchEngine},
+		{"Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; GPTBot/1.2; +https://openai.com/gptbot)", "GPTBot", BotAICrawler},
+		{"Mozilla/5.0 (compatible; Yahoo! Slurp; http://help.yahoo.com/help/us/ysearch/slurp)", "Yahoo! Slurp", BotSearchEngine},
+		{"Mozilla/5.0 (compatible; Pingdom.com_bot_version_1.4_(http://www.pingdom.com/))", "Pingdom", BotMonitor},
+		{"curl/8.4.0", "curl", BotLibrary},
+		{"node", "node", BotLibrary},
+		{"Mozilla/5.0 (compatible; ExampleCrawler/1.0)", "ExampleCrawler", BotScraper},
+		// Libraries are only recognised at the start
+		{"Mozilla/5.0 curl/8.4.0", "", ""},
+		// The generic signature is case-sensitive
+		{"Mozilla/5.0 (Linux; Android 13; CUBOT KINGKONG 9)", "", ""},
+		{chromeWindows, "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		var info DeviceInfo
+		matchBot(bots, tt.ua, &info)
+		if info.IsBot != (tt.name != "") || str(info.BotName) != tt.name || str(info.BotCategory) != tt.category {
+			t.Errorf("matchBot(%q) = %v %q %q, want %q %q", tt.ua, info.IsBot, str(info.BotName), str(info.BotCategory), tt.name, tt.category)
+		}
+
+		// The literals never rule out a signature that matches
+		for i := range bots {
+			if bots[i].re.MatchString(tt.ua) && !bots[i].mayMatch(tt.ua) {
+				t.Errorf("%s matches %q but its literals %q do not", bots[i].re, tt.ua, bots[i].literals)
+			}
+		}
+	}
+}
+
+func TestRequiredLiterals(t *testing.T) {
+	tests := []struct {
+		expr     string
+		literals []string
+		fold     bool
+	}{
+		{`(Googlebot(?:-Image|-Video|))`, []string{"Googlebot"}, false},
+		{`(?i)(bingbot|BingPreview)`, []string{"bing"}, true},
+		{`^(axios|got)(?:/|$)`, []string{"axios", "got"}, false},
+		{`\b([A-Za-z][\w.-]*(?:[Bb]ot|[Cc]rawler))\b`, []string{"ot", "rawler"}, false},
+		{`(?:x+)(Scrapy)/`, []string{"Scrapy"}, false},
+		// Nothing is required when an alternative or the whole match can
+		// be empty
+		{`(foo|\d+)`, nil, false},
+		{`(?:foo)?bar*`, []string{"ba"}, false},
+		{`\w+`, nil, false},
+	}
+	for _, tt := range tests {
+		parsed, err := syntax.Parse(tt.expr, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		literals, fold := requiredLiterals(parsed)
+		if !reflect.DeepEqual(literals, tt.literals) || fold != tt.fold {
+			t.Errorf("requiredLiterals(%s) = %q, %v; want %q, %v", tt.expr, literals, fold, tt.literals, tt.fold)
+		}
+	}
+}
+
+func BenchmarkMatchBot(b *testing.B) {
+	p := NewDefault(0)
+	uas := []struct{ name, ua string }{
+		{"chrome", chromeWindows},
+		{"safari", safariIPhone},
+		{"googlebot", googlebot},
+		{"curl", "curl/8.4.0"},
+	}
+	for _, tt := range uas {
+		b.Run(tt.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var info DeviceInfo
+				matchBot(p.bots, tt.ua, &info)
+			}
+		})
+	}
+}
+
+func TestDeviceInfoBotVerified(t *testing.T) {
+	verified := false
+	d := DeviceInfo{IsBot: true, BotName: ptr("Googlebot"), BotVerified: &verified}
+	if got := d.ToMap()["botVerified"]; got != false {
+		t.Errorf("ToMap()[botVerified] = %v, want false", got)
+	}
+	if !strings.Contains(d.String(), "BotVerified: false\n") {
+		t.Errorf("String() = %s", d)
+	}
+
+	// Unchecked bots have no result, as in JSON
+	d.BotVerified = nil
+	if _, ok := d.ToMap()["botVerified"]; ok {
+		t.Error("ToMap() has botVerified for an unchecked bot")
+	}
+	if !strings.Contains(d.String(), "BotVerified: null\n") {
+		t.Errorf("String() = %s", d)
+	}
+}
@@ -0,0 +1,131 @@
+package useragent
+
+import "fmt"
+
+// DeviceInfo represents detailed information about the user's device.
+// Values returned by a Parser are shared with its cache and must be treated
+// as read-only.
+type DeviceInfo struct {
+	OS             string  `json:"os"`                    // Operating system name
+	Platform       string  `json:"platform"`              // Platform (Desktop/Mobile/Tablet/TV/Console/Wearable/Car)
+	Version        *string `json:"version"`               // OS version (nullable)
+	Browser        *string `json:"browser"`               // Browser name (nullable)
+	BrowserVersion *string `json:"browserVersion"`        // Browser version (nullable)
+	Engine         *string `json:"engine"`                // Browser engine (nullable)
+	EngineVersion  *string `json:"engineVersion"`         // Engine version (nullable)
+	Architecture   *string `json:"architecture"`          // CPU architecture (nullable)
+	App            *string `json:"app"`                   // Native app name (nullable)
+	AppVersion     *string `json:"appVersion"`            // Native app version (nullable)
+	Client         *string `json:"client"`                // HTTP client library, e.g. OkHttp or CFNetwork (nullable)
+	ClientVersion  *string `json:"clientVersion"`         // HTTP client library version (nullable)
+	DeviceVendor   *string `json:"deviceVendor"`          // Device manufacturer (nullable)
+	DeviceModel    *string `json:"deviceModel"`           // Marketing name, or the model identifier when unknown (nullable)
+	DeviceType     *string `json:"deviceType"`            // smartphone, tablet, tv, console, wearable, car or desktop (nullable)
+	IsBot          bool    `json:"isBot"`                 // Crawler, monitor, headless browser or HTTP library
+	BotName        *string `json:"botName"`               // Bot name (nullable)
+	BotCategory    *string `json:"botCategory"`           // search_engine, ai_crawler, monitor, scraper or library (nullable)
+	BotVerified    *bool   `json:"botVerified,omitempty"` // Forward-confirmed reverse DNS result, when checked
+
+	// modelCode is the raw model identifier, e.g. SM-S918B
+	modelCode *string
+
+	// botDomains are the verification domains of the matched bot signature
+	botDomains []string
+}
+
+// String implements the Stringer interface for pretty printing
+func (d DeviceInfo) String() string {
+	return fmt.Sprintf(
+		"DeviceInfo{\n"+
+			"  OS: %s\n"+
+			"  Platform: %s\n"+
+			"  Version: %s\n"+
+			"  Browser: %s\n"+
+			"  BrowserVersion: %s\n"+
+			"  Engine: %s\n"+
+			"  EngineVersion: %s\n"+
+			"  Architecture: %s\n"+
+			"  App: %s\n"+
+			"  AppVersion: %s\n"+
+			"  Client: %s\n"+
+			"  ClientVersion: %s\n"+
+			"  DeviceVendor: %s\n"+
+			"  DeviceModel: %s\n"+
+			"  DeviceType: %s\n"+
+			"  IsBot: %t\n"+
+			"  BotName: %s\n"+
+			"  BotCategory: %s\n"+
+			"  BotVerified: %s\n"+
+			"}",
+		d.OS,
+		d.Platform,
+		orNull(d.Version),
+		orNull(d.Browser),
+		orNull(d.BrowserVersion),
+		orNull(d.Engine),
+		orNull(d.EngineVersion),
+		orNull(d.Architecture),
+		orNull(d.App),
+		orNull(d.AppVersion),
+		orNull(d.Client),
+		orNull(d.ClientVersion),
+		orNull(d.DeviceVendor),
+		orNull(d.DeviceModel),
+		orNull(d.DeviceType),
+		d.IsBot,
+		orNull(d.BotName),
+		orNull(d.BotCategory),
+		orNull(d.BotVerified),
+	)
+}
+
+// ToMap converts DeviceInfo to a map for easy access. Like the JSON form,
+// it has botVerified only when the bot was checked.
+func (d DeviceInfo) ToMap() map[string]interface{} {
+	m := map[string]interface{}{
+		"os":             d.OS,
+		"platform":       d.Platform,
+		"version":        orNil(d.Version),
+		"browser":        orNil(d.Browser),
+		"browserVersion": orNil(d.BrowserVersion),
+		"engine":         orNil(d.Engine),
+		"engineVersion":  orNil(d.EngineVersion),
+		"architecture":   orNil(d.Architecture),
+		"app":            orNil(d.App),
+		"appVersion":     orNil(d.AppVersion),
+		"client":         orNil(d.Client),
+		"clientVersion":  orNil(d.ClientVersion),
+		"deviceVendor":   orNil(d.DeviceVendor),
+		"deviceModel":    orNil(d.DeviceModel),
+		"deviceType":     orNil(d.DeviceType),
+		"isBot":          d.IsBot,
+		"botName":        orNil(d.BotName),
+		"botCategory":    orNil(d.BotCategory),
+	}
+	if d.BotVerified != nil {
+		m["botVerified"] = *d.BotVerified
+	}
+	return m
+}
+
+func orNull[T any](v *T) string {
+	if v == nil {
+		return "null"
+	}
+	return fmt.Sprint(*v)
+}
+
+func orNil[T any](v *T) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// ptr returns a pointer to s, or nil when s is empty
+func ptr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,183 @@
+package useragent
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Device types
+const (
+	DeviceSmartphone = "smartphone"
+	DeviceTablet     = "tablet"
+	DeviceTV         = "tv"
+	DeviceConsole    = "console"
+	DeviceWearable   = "wearable"
+	DeviceCar        = "car"
+	DeviceDesktop    = "desktop"
+)
+
+// platforms maps device types to the coarse DeviceInfo.Platform values
+var platforms = map[string]string{
+	DeviceSmartphone: "Mobile",
+	DeviceTablet:     "Tablet",
+	DeviceTV:         "TV",
+	DeviceConsole:    "Console",
+	DeviceWearable:   "Wearable",
+	DeviceCar:        "Car",
+	DeviceDesktop:    "Desktop",
+}
+
+// DeviceModel is an entry of the model database. Code is matched as a
+// case-insensitive prefix of the model identifier, longest first, so
+// "SM-S918" covers every regional variant such as SM-S918B and SM-S918U.
+// An empty Name keeps the identifier itself.
+type DeviceModel struct {
+	Code   string `yaml:"code"`
+	Vendor string `yaml:"vendor"`
+	Name   string `yaml:"name"`
+	Type   string `yaml:"type"`
+}
+
+type compiledDevice struct {
+	re         *regexp.Regexp
+	device     string
+	brand      string
+	model      string
+	deviceType string
+}
+
+func compileDevices(rules []Rule) ([]compiledDevice, error) {
+	compiled := make([]compiledDevice, 0, len(rules))
+	for _, rule := range rules {
+		re, err := compileRegex(rule.Regex, rule.RegexFlag)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, compiledDevice{
+			re:         re,
+			device:     rule.DeviceReplacement,
+			brand:      rule.BrandReplacement,
+			model:      rule.ModelReplacement,
+			deviceType: rule.DeviceType,
+		})
+	}
+	return compiled, nil
+}
+
+// matchDevice fills in the vendor, model identifier and device type from the
+// first matching device rule, following ua-parser semantics: the model falls
+// back to the device name, which falls back to the first capture group
+func matchDevice(devices []compiledDevice, ua string, info *DeviceInfo) {
+	for i := range devices {
+		rule := &devices[i]
+		loc := rule.re.FindStringSubmatchIndex(ua)
+		if loc == nil {
+			continue
+		}
+
+		device := group(ua, loc, 1)
+		if rule.device != "" {
+			device = expand(rule.device, ua, loc)
+		}
+		model := device
+		if rule.model != "" {
+			model = expand(rule.model, ua, loc)
+		}
+
+		info.DeviceVendor = ptr(expand(rule.brand, ua, loc))
+		info.modelCode = ptr(model)
+		info.DeviceType = ptr(rule.deviceType)
+		return
+	}
+}
+
+// modelIndex resolves model identifiers to marketing names
+type modelIndex []DeviceModel
+
+func newModelIndex(models []DeviceModel) modelIndex {
+	index := make(modelIndex, len(models))
+	for i, model := range models {
+		model.Code = strings.ToUpper(model.Code)
+		index[i] = model
+	}
+	// Longest prefix first, then alphabetically for a stable order
+	sort.SliceStable(index, func(i, j int) bool {
+		if len(index[i].Code) != len(index[j].Code) {
+			return len(index[i].Code) > len(index[j].Code)
+		}
+		return index[i].Code < index[j].Code
+	})
+	return index
+}
+
+func (idx modelIndex) lookup(code string) (DeviceModel, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DeviceModel{}, false
+	}
+	for _, model := range idx {
+		if strings.HasPrefix(code, model.Code) {
+			return model, true
+		}
+	}
+	return DeviceModel{}, false
+}
+
+// resolveDevice completes the device fields once the user agent and any
+// client hints have been applied: it looks the model identifier up in the
+// model database, infers a form factor when no rule provided one, and
+// derives Platform from the device type
+func (p *Parser) resolveDevice(ua string, info *DeviceInfo) {
+	if info.modelCode != nil {
+		if model, ok := p.models.lookup(*info.modelCode); ok {
+			if model.Vendor != "" {
+				info.DeviceVendor = ptr(model.Vendor)
+			}
+			if model.Name != "" {
+				info.DeviceModel = ptr(model.Name)
+			}
+			if model.Type != "" {
+				info.DeviceType = ptr(model.Type)
+			}
+		}
+		if info.DeviceModel == nil {
+			info.DeviceModel = info.modelCode
+		}
+	}
+
+	if info.DeviceType == nil && !info.IsBot {
+		info.DeviceType = ptr(inferDeviceType(ua, info.OS))
+	}
+
+	if info.DeviceType != nil {
+		if platform, ok := platforms[*info.DeviceType]; ok {
+			info.Platform = platform
+			return
+		}
+	}
+	if info.OS == "Unknown" && info.Browser == nil {
+		// HTTP libraries such as okhttp or Dart say nothing about the device
+		info.Platform = "Unknown"
+		return
+	}
+	info.Platform = platform(ua)
+}
+
+// inferDeviceType guesses the form factor from the OS and the Mobile and
+// Tablet tokens. Chrome on Android omits "Mobile" on tablets.
+func inferDeviceType(ua, os string) string {
+	lower := strings.ToLower(ua)
+	switch {
+	case strings.Contains(lower, "tablet"):
+		return DeviceTablet
+	case os == "Android" && !strings.Contains(lower, "mobile"):
+		return DeviceTablet
+	case strings.Contains(lower, "mobile"), os == "iOS", os == "Android", os == "Windows Phone":
+		return DeviceSmartphone
+	case os == "Windows", os == "MacOS", os == "Linux", os == "Chrome OS", os == "Solaris",
+		os == "FreeBSD", os == "OpenBSD", os == "NetBSD":
+		return DeviceDesktop
+	}
+	return ""
+}
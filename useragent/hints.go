@@ -0,0 +1,310 @@
+package useragent
+
+import (
+	"strconv"
+	"strings"
+)
+
+// User-Agent Client Hints request headers
+const (
+	HeaderSecCHUA                = "Sec-CH-UA"
+	HeaderSecCHUAMobile          = "Sec-CH-UA-Mobile"
+	HeaderSecCHUAPlatform        = "Sec-CH-UA-Platform"
+	HeaderSecCHUAPlatformVersion = "Sec-CH-UA-Platform-Version"
+	HeaderSecCHUAModel           = "Sec-CH-UA-Model"
+	HeaderSecCHUAArch            = "Sec-CH-UA-Arch"
+	HeaderSecCHUABitness         = "Sec-CH-UA-Bitness"
+	HeaderSecCHUAFullVersionList = "Sec-CH-UA-Full-Version-List"
+)
+
+// AcceptCH lists the high-entropy hints a server should request with the
+// Accept-CH response header; the low-entropy ones are sent by default
+var AcceptCH = []string{
+	HeaderSecCHUAPlatformVersion,
+	HeaderSecCHUAModel,
+	HeaderSecCHUAArch,
+	HeaderSecCHUABitness,
+	HeaderSecCHUAFullVersionList,
+}
+
+// CriticalCH lists the hints worth a request retry when missing, since the
+// reduced User-Agent string cannot provide them at all
+var CriticalCH = []string{
+	HeaderSecCHUAPlatformVersion,
+	HeaderSecCHUAModel,
+}
+
+// ClientHints holds the raw User-Agent Client Hints header values
+type ClientHints struct {
+	UA              string
+	Mobile          string
+	Platform        string
+	PlatformVersion string
+	Model           string
+	Arch            string
+	Bitness         string
+	FullVersionList string
+}
+
+// HintsFromHeaders collects the hints from a header lookup function such as
+// http.Header.Get. The function is called with the canonical names above.
+func HintsFromHeaders(get func(name string) string) ClientHints {
+	return ClientHints{
+		UA:              get(HeaderSecCHUA),
+		Mobile:          get(HeaderSecCHUAMobile),
+		Platform:        get(HeaderSecCHUAPlatform),
+		PlatformVersion: get(HeaderSecCHUAPlatformVersion),
+		Model:           get(HeaderSecCHUAModel),
+		Arch:            get(HeaderSecCHUAArch),
+		Bitness:         get(HeaderSecCHUABitness),
+		FullVersionList: get(HeaderSecCHUAFullVersionList),
+	}
+}
+
+// IsZero reports whether no hints were sent
+func (h ClientHints) IsZero() bool {
+	return h == ClientHints{}
+}
+
+// appendCacheKey appends the user agent and hints to key, separated by NULs
+func (h ClientHints) appendCacheKey(key []byte, ua string) []byte {
+	for i, s := range [...]string{
+		ua, h.UA, h.Mobile, h.Platform, h.PlatformVersion, h.Model, h.Arch, h.Bitness, h.FullVersionList,
+	} {
+		if i > 0 {
+			key = append(key, 0)
+		}
+		key = append(key, s...)
+	}
+	return key
+}
+
+// brandNames maps Sec-CH-UA brands to the browser names the rules produce
+var brandNames = map[string]string{
+	"Google Chrome":    "Chrome",
+	"Microsoft Edge":   "Edge",
+	"Opera":            "Opera",
+	"Opera GX":         "Opera",
+	"Brave":            "Brave",
+	"Vivaldi":          "Vivaldi",
+	"YaBrowser":        "Yandex Browser",
+	"Yandex":           "Yandex Browser",
+	"Samsung Internet": "Samsung Internet",
+	"HeadlessChrome":   "HeadlessChrome",
+	"Android WebView":  "Chrome WebView",
+	"Chromium":         "Chromium",
+}
+
+// platformNames maps Sec-CH-UA-Platform values to the OS names the rules produce
+var platformNames = map[string]string{
+	"Windows":   "Windows",
+	"macOS":     "MacOS",
+	"Linux":     "Linux",
+	"Android":   "Android",
+	"iOS":       "iOS",
+	"Chrome OS": "Chrome OS",
+	"ChromeOS":  "Chrome OS",
+	"Fuchsia":   "Fuchsia",
+}
+
+// applyHints overrides the fields parsed from the User-Agent string with the
+// more accurate values from client hints
+func applyHints(info *DeviceInfo, h ClientHints) {
+	brands := parseBrandList(h.FullVersionList)
+	if len(brands) == 0 {
+		brands = parseBrandList(h.UA)
+	}
+	if name, version := pickBrand(brands); name != "" {
+		info.Browser = ptr(name)
+		info.BrowserVersion = ptr(version)
+		if info.Engine == nil || *info.Engine == "Blink" {
+			info.Engine = ptr("Blink")
+			info.EngineVersion = ptr(chromiumVersion(brands))
+		}
+	}
+
+	if platform, ok := platformNames[parseString(h.Platform)]; ok {
+		info.OS = platform
+		info.Version = nil
+		if version := platformVersion(platform, parseString(h.PlatformVersion)); version != "" {
+			info.Version = &version
+		}
+	}
+
+	// Chromium reports ?0 on Android tablets as well as on desktops
+	switch strings.TrimSpace(h.Mobile) {
+	case "?1":
+		if info.DeviceType == nil || *info.DeviceType != DeviceTablet {
+			info.DeviceType = ptr(DeviceSmartphone)
+		}
+	case "?0":
+		if info.DeviceType == nil || *info.DeviceType == DeviceSmartphone {
+			if info.OS == "Android" {
+				info.DeviceType = ptr(DeviceTablet)
+			} else {
+				info.DeviceType = ptr(DeviceDesktop)
+			}
+		}
+	}
+
+	if model := parseString(h.Model); model != "" {
+		info.modelCode = &model
+	}
+
+	if arch := hintArch(parseString(h.Arch), parseString(h.Bitness)); arch != "" {
+		info.Architecture = &arch
+	}
+}
+
+type brand struct {
+	name    string
+	version string
+}
+
+// pickBrand returns the most specific brand: anything other than the
+// Chromium base, ignoring GREASE entries
+func pickBrand(brands []brand) (string, string) {
+	var fallback brand
+	for _, b := range brands {
+		if isGrease(b.name) {
+			continue
+		}
+		name, ok := brandNames[b.name]
+		if !ok {
+			name = b.name
+		}
+		if b.name == "Chromium" {
+			fallback = brand{name, b.version}
+			continue
+		}
+		return name, b.version
+	}
+	return fallback.name, fallback.version
+}
+
+func chromiumVersion(brands []brand) string {
+	for _, b := range brands {
+		if b.name == "Chromium" {
+			return b.version
+		}
+	}
+	return ""
+}
+
+// isGrease detects the intentionally malformed brands browsers add, such as
+// "Not_A Brand" or "Not?A_Brand"
+func isGrease(name string) bool {
+	return strings.HasPrefix(name, "Not") && strings.Contains(name, "Brand")
+}
+
+// platformVersion translates Sec-CH-UA-Platform-Version to a marketing version
+func platformVersion(platform, version string) string {
+	if version == "" {
+		return ""
+	}
+	if platform != "Windows" {
+		return strings.TrimSuffix(strings.TrimSuffix(version, ".0"), ".0")
+	}
+
+	// Windows reports the UniversalApiContract version: 13 and above is
+	// Windows 11, 1 to 10 is Windows 10, and 0.x covers 7, 8 and 8.1
+	parts := strings.SplitN(version, ".", 3)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return ""
+	}
+	switch {
+	case major >= 13:
+		return "11"
+	case major > 0:
+		return "10"
+	}
+	if len(parts) > 1 {
+		switch parts[1] {
+		case "1":
+			return "7"
+		case "2":
+			return "8"
+		case "3":
+			return "8.1"
+		}
+	}
+	return ""
+}
+
+func hintArch(arch, bitness string) string {
+	switch {
+	case arch == "x86" && bitness == "64":
+		return "x86_64"
+	case arch == "x86":
+		return "x86"
+	case arch == "arm" && bitness == "64":
+		return "ARM64"
+	case arch == "arm":
+		return "ARM"
+	}
+	return ""
+}
+
+// parseBrandList parses a structured-field list such as
+// "Chromium";v="120", "Google Chrome";v="120", "Not?A_Brand";v="8"
+func parseBrandList(s string) []brand {
+	var brands []brand
+	for _, item := range splitList(s) {
+		params := strings.Split(item, ";")
+		b := brand{name: parseString(params[0])}
+		for _, param := range params[1:] {
+			if key, value, ok := strings.Cut(strings.TrimSpace(param), "="); ok && key == "v" {
+				b.version = parseString(value)
+			}
+		}
+		if b.name != "" {
+			brands = append(brands, b)
+		}
+	}
+	return brands
+}
+
+// splitList splits a structured-field list on commas outside quoted strings
+func splitList(s string) []string {
+	var (
+		items  []string
+		quoted bool
+		start  int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				items = append(items, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(items, s[start:])
+}
+
+// parseString unquotes a structured-field string, tolerating bare tokens
+func parseString(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+
+	s = s[1 : len(s)-1]
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
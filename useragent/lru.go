@@ -0,0 +1,69 @@
+package useragent
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lru is a fixed-size, concurrency-safe cache. A hit does not allocate.
+type lru[V any] struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+func newLRU[V any](size int) *lru[V] {
+	return &lru[V]{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *lru[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hit(c.entries[key])
+}
+
+// getBytes is get with the key in a byte slice, which is not copied
+func (c *lru[V]) getBytes(key []byte) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hit(c.entries[string(key)])
+}
+
+func (c *lru[V]) hit(elem *list.Element) (V, bool) {
+	if elem == nil {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[V]).value, true
+}
+
+func (c *lru[V]) add(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry[V]).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[V]).key)
+	}
+}
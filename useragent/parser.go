@@ -0,0 +1,231 @@
+// Package useragent parses User-Agent strings into DeviceInfo using ordered,
+// precompiled rules in the ua-parser regexes.yaml format.
+package useragent
+
+import (
+	"strings"
+)
+
+// DefaultCacheSize is the number of parsed user agents kept by default
+const DefaultCacheSize = 10000
+
+// maxUALength bounds the input passed to the regex engine and the size of
+// cache keys; real user agents are far shorter
+const maxUALength = 1024
+
+// Parser turns User-Agent strings into DeviceInfo. Rules are evaluated in
+// file order and the first match wins, so results are deterministic. It is
+// safe for concurrent use.
+type Parser struct {
+	userAgent []compiledRule
+	os        []compiledRule
+	engine    []compiledRule
+	arch      []compiledRule
+	client    []compiledRule
+	app       []compiledRule
+	device    []compiledDevice
+	models    modelIndex
+	bots      []compiledBot
+	cache     *lru[DeviceInfo]
+}
+
+// New compiles rules into a Parser that caches up to cacheSize results.
+// A cacheSize of zero or less disables the cache.
+func New(rules *RuleSet, cacheSize int) (*Parser, error) {
+	var (
+		p   Parser
+		err error
+	)
+
+	p.userAgent, err = compileRules(rules.UserAgent, familyReplacements)
+	if err != nil {
+		return nil, err
+	}
+
+	p.os, err = compileRules(rules.OS, func(r Rule) [4]string {
+		return [4]string{r.OSReplacement, r.OSV1Replacement, r.OSV2Replacement, r.OSV3Replacement}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.engine, err = compileRules(rules.Engine, func(r Rule) [4]string {
+		return [4]string{r.EngineReplacement, r.EngineV1Replacement, r.EngineV2Replacement}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.arch, err = compileRules(rules.Arch, func(r Rule) [4]string {
+		return [4]string{r.ArchReplacement}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.client, err = compileRules(rules.Client, familyReplacements)
+	if err != nil {
+		return nil, err
+	}
+
+	p.app, err = compileRules(rules.App, familyReplacements)
+	if err != nil {
+		return nil, err
+	}
+
+	p.device, err = compileDevices(rules.Device)
+	if err != nil {
+		return nil, err
+	}
+	p.models = newModelIndex(rules.Models)
+
+	p.bots, err = compileBots(rules.Bots)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheSize > 0 {
+		p.cache = newLRU[DeviceInfo](cacheSize)
+	}
+	return &p, nil
+}
+
+func familyReplacements(r Rule) [4]string {
+	return [4]string{r.FamilyReplacement, r.V1Replacement, r.V2Replacement, r.V3Replacement}
+}
+
+// NewDefault creates a Parser from the embedded rules
+func NewDefault(cacheSize int) *Parser {
+	p, err := New(DefaultRules(), cacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// unknown is returned for empty user agents without touching the cache
+var unknown = DeviceInfo{OS: "Unknown", Platform: "Unknown"}
+
+// Parse returns the device information for a User-Agent string. Repeated
+// user agents are served from the cache without allocating.
+func (p *Parser) Parse(userAgent string) DeviceInfo {
+	return p.ParseWithHints(userAgent, ClientHints{})
+}
+
+// ParseWithHints is like Parse but prefers User-Agent Client Hints over the
+// User-Agent string where they are present
+func (p *Parser) ParseWithHints(userAgent string, hints ClientHints) DeviceInfo {
+	if userAgent == "" && hints.IsZero() {
+		return unknown
+	}
+	if len(userAgent) > maxUALength {
+		userAgent = userAgent[:maxUALength]
+	}
+
+	// The hints are keyed in a buffer on the stack, so that a hit does not
+	// allocate with them either
+	var buf [2 * maxUALength]byte
+	var key []byte
+	if !hints.IsZero() {
+		key = hints.appendCacheKey(buf[:0], userAgent)
+	}
+
+	if p.cache != nil {
+		if info, ok := p.cachedInfo(userAgent, key); ok {
+			return info
+		}
+	}
+
+	info := p.parse(userAgent)
+	if !hints.IsZero() {
+		applyHints(&info, hints)
+	}
+	p.resolveDevice(userAgent, &info)
+
+	if p.cache != nil {
+		if key != nil {
+			p.cache.add(string(key), info)
+		} else {
+			// Clone the key so the cache does not pin a larger request buffer
+			p.cache.add(strings.Clone(userAgent), info)
+		}
+	}
+	return info
+}
+
+// cachedInfo looks up a user agent, or its hints key when there is one
+func (p *Parser) cachedInfo(userAgent string, key []byte) (DeviceInfo, bool) {
+	if key != nil {
+		return p.cache.getBytes(key)
+	}
+	return p.cache.get(userAgent)
+}
+
+func (p *Parser) parse(ua string) DeviceInfo {
+	info := DeviceInfo{OS: "Unknown"}
+
+	if fields, ok := match(p.os, ua); ok {
+		info.OS = fields[0]
+		info.Version = ptr(joinVersion(fields[1:]))
+		if info.OS == "Darwin" {
+			darwinOS(ua, fields, &info)
+		}
+	}
+
+	if fields, ok := match(p.userAgent, ua); ok {
+		info.Browser = ptr(fields[0])
+		info.BrowserVersion = ptr(joinVersion(fields[1:]))
+	}
+
+	if fields, ok := match(p.engine, ua); ok {
+		info.Engine = ptr(fields[0])
+		info.EngineVersion = ptr(joinVersion(fields[1:]))
+	}
+
+	if fields, ok := match(p.arch, ua); ok {
+		info.Architecture = ptr(fields[0])
+	}
+
+	if fields, ok := match(p.client, ua); ok {
+		info.Client = ptr(fields[0])
+		info.ClientVersion = ptr(joinVersion(fields[1:]))
+	}
+
+	matchDevice(p.device, ua, &info)
+	matchBot(p.bots, ua, &info)
+
+	if !info.IsBot {
+		p.matchApp(ua, &info)
+	}
+
+	return info
+}
+
+// platform classifies the form factor from the user agent when no device
+// type is known
+func platform(ua string) string {
+	lower := strings.ToLower(ua)
+	switch {
+	case strings.Contains(lower, "tablet"):
+		return "Tablet"
+	case strings.Contains(lower, "mobile"):
+		return "Mobile"
+	default:
+		return "Desktop"
+	}
+}
+
+// joinVersion joins the leading non-empty version components with dots
+func joinVersion(parts []string) string {
+	version := ""
+	for _, part := range parts {
+		if part == "" {
+			break
+		}
+		if version != "" {
+			version += "."
+		}
+		version += part
+	}
+	return version
+}
@@ -0,0 +1,430 @@
+package useragent
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const (
+	chromeWindows  = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	safariMac      = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Safari/605.1.15"
+	edgeWindows    = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91"
+	firefoxWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0"
+	safariIPhone   = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1"
+	chromeAndroid  = "Mozilla/5.0 (Linux; Android 14; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.144 Mobile Safari/537.36"
+	chromeIPad     = "Mozilla/5.0 (iPad; CPU OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/120.0.6099.119 Mobile/15E148 Safari/604.1"
+	googlebot      = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+)
+
+// str dereferences an optional field, "" standing for nil
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name, ua                               string
+		os, version, platform                  string
+		browser, browserVersion, engine        string
+		vendor, model, deviceType, botName     string
+		client, clientVersion, app, appVersion string
+	}{
+		{
+			name: "chrome", ua: chromeWindows,
+			os: "Windows", version: "10", platform: "Desktop",
+			browser: "Chrome", browserVersion: "120.0.0", engine: "Blink", deviceType: DeviceDesktop,
+		},
+		{
+			// Safari must not be mistaken for Chrome, nor Chrome for Safari
+			name: "safari", ua: safariMac,
+			os: "MacOS", version: "10.15.7", platform: "Desktop",
+			browser: "Safari", browserVersion: "17.2", engine: "WebKit", deviceType: DeviceDesktop,
+		},
+		{
+			// Edge also sends the Chrome token
+			name: "edge", ua: edgeWindows,
+			os: "Windows", version: "10", platform: "Desktop",
+			browser: "Edge", browserVersion: "120.0.2210", engine: "Blink", deviceType: DeviceDesktop,
+		},
+		{
+			name: "firefox", ua: firefoxWindows,
+			os: "Windows", version: "10", platform: "Desktop",
+			browser: "Firefox", browserVersion: "121.0", engine: "Gecko", deviceType: DeviceDesktop,
+		},
+		{
+			name: "safari iphone", ua: safariIPhone,
+			os: "iOS", version: "17.2", platform: "Mobile",
+			browser: "Safari", browserVersion: "17.2", engine: "WebKit",
+			vendor: "Apple", model: "iPhone", deviceType: DeviceSmartphone,
+		},
+		{
+			name: "chrome android", ua: chromeAndroid,
+			os: "Android", version: "14", platform: "Mobile",
+			browser: "Chrome", browserVersion: "120.0.6099", engine: "Blink",
+			vendor: "Samsung", model: "Galaxy S23 Ultra", deviceType: DeviceSmartphone,
+		},
+		{
+			// Chrome on iOS is WebKit underneath
+			name: "chrome ipad", ua: chromeIPad,
+			os: "iOS", version: "17.2", platform: "Tablet",
+			browser: "Chrome", browserVersion: "120.0.6099", engine: "WebKit",
+			vendor: "Apple", model: "iPad", deviceType: DeviceTablet,
+		},
+		{
+			name: "googlebot", ua: googlebot,
+			os: "Unknown", platform: "Unknown", botName: "Googlebot",
+		},
+		{
+			// Libraries inside native apps are the client, not a bot
+			name: "okhttp", ua: "okhttp/4.12.0",
+			os: "Unknown", platform: "Unknown",
+			client: "OkHttp", clientVersion: "4.12.0",
+		},
+		{
+			name: "okhttp app", ua: "MyApp/5.1 (Android 14; Pixel 8) okhttp/4.12.0",
+			os: "Android", version: "14", platform: "Mobile",
+			vendor: "Google", model: "Pixel 8", deviceType: DeviceSmartphone,
+			client: "OkHttp", clientVersion: "4.12.0", app: "MyApp", appVersion: "5.1",
+		},
+		{
+			// The Darwin version gives the iOS release
+			name: "cfnetwork ios", ua: "MyApp/5.1.2 CFNetwork/1490.0.4 Darwin/23.2.0",
+			os: "iOS", version: "17.2", platform: "Mobile", deviceType: DeviceSmartphone,
+			client: "CFNetwork", clientVersion: "1490.0.4", app: "MyApp", appVersion: "5.1.2",
+		},
+		{
+			// macOS clients append the architecture
+			name: "cfnetwork macos", ua: "MyApp/5.1.2 CFNetwork/1490.0.4 Darwin/23.2.0 (x86_64)",
+			os: "MacOS", version: "14.2", platform: "Desktop", deviceType: DeviceDesktop,
+			client: "CFNetwork", clientVersion: "1490.0.4", app: "MyApp", appVersion: "5.1.2",
+		},
+		{
+			name: "encoded app name", ua: "Weather%20Pro/3.0 CFNetwork/1410.0.3 Darwin/22.6.0",
+			os: "iOS", version: "16.6", platform: "Mobile", deviceType: DeviceSmartphone,
+			client: "CFNetwork", clientVersion: "1410.0.3", app: "Weather Pro", appVersion: "3.0",
+		},
+		{
+			// The library's own token is not an app
+			name: "dart", ua: "Dart/3.2 (dart:io)",
+			os: "Unknown", platform: "Unknown",
+			client: "Dart", clientVersion: "3.2",
+		},
+		{
+			name: "app", ua: "MyApp/5.1 (iPhone; iOS 17.2)",
+			os: "iOS", version: "17.2", platform: "Mobile",
+			vendor: "Apple", model: "iPhone", deviceType: DeviceSmartphone,
+			app: "MyApp", appVersion: "5.1",
+		},
+		{
+			// Command-line clients and scripts are bots as well
+			name: "curl", ua: "curl/8.4.0",
+			os: "Unknown", platform: "Unknown", botName: "curl",
+			client: "curl", clientVersion: "8.4.0",
+		},
+		{
+			name: "python-requests", ua: "python-requests/2.31.0",
+			os: "Unknown", platform: "Unknown", botName: "python-requests",
+			client: "python-requests", clientVersion: "2.31.0",
+		},
+		{
+			name: "empty", ua: "",
+			os: "Unknown", platform: "Unknown",
+		},
+	}
+
+	p := NewDefault(DefaultCacheSize)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The second parse is served from the cache and must agree
+			for i := 0; i < 2; i++ {
+				info := p.Parse(tt.ua)
+				got := []string{info.OS, str(info.Version), info.Platform, str(info.Browser), str(info.BrowserVersion), str(info.Engine), str(info.DeviceVendor), str(info.DeviceModel), str(info.DeviceType), str(info.BotName),
+					str(info.Client), str(info.ClientVersion), str(info.App), str(info.AppVersion)}
+				want := []string{tt.os, tt.version, tt.platform, tt.browser, tt.browserVersion, tt.engine, tt.vendor, tt.model, tt.deviceType, tt.botName,
+					tt.client, tt.clientVersion, tt.app, tt.appVersion}
+				if strings.Join(got, "|") != strings.Join(want, "|") {
+					t.Errorf("Parse() = %q, want %q", got, want)
+				}
+				if info.IsBot != (tt.botName != "") {
+					t.Errorf("IsBot = %v", info.IsBot)
+				}
+			}
+		})
+	}
+}
+
+func TestDarwinOS(t *testing.T) {
+	tests := []struct {
+		ua, os, version string
+	}{
+		{"CFNetwork/1490.0.4 Darwin/23.2.0", "iOS", "17.2"},
+		{"CFNetwork/1568.100.1 Darwin/24.0.0", "iOS", "18.0"},
+		{"CFNetwork/1490.0.4 Darwin/23.2.0 (arm64)", "MacOS", "14.2"},
+		{"CFNetwork/1128.0.1 Darwin/19.6.0 (x86_64)", "MacOS", "10.15"},
+		// Darwin 25 is iOS and macOS 26
+		{"CFNetwork/3826.400.120 Darwin/25.0.0", "iOS", "26.0"},
+		{"CFNetwork/3826.400.120 Darwin/25.0.0 (arm64)", "MacOS", "26.0"},
+	}
+	p := NewDefault(0)
+	for _, tt := range tests {
+		if info := p.Parse(tt.ua); info.OS != tt.os || str(info.Version) != tt.version {
+			t.Errorf("Parse(%q) = %s %s, want %s %s", tt.ua, info.OS, str(info.Version), tt.os, tt.version)
+		}
+	}
+}
+
+// reducedAndroid is the frozen user agent Chrome sends on Android, which
+// hides the OS version and model
+const reducedAndroid = "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36"
+
+func TestParseWithHints(t *testing.T) {
+	tests := []struct {
+		name, ua                        string
+		hints                           ClientHints
+		os, version, platform           string
+		browser, browserVersion, engine string
+		vendor, model, deviceType, arch string
+	}{
+		{
+			// GREASE brands are skipped, so the Chromium base is reported
+			name: "grease", ua: reducedAndroid,
+			os: "Android", platform: "Mobile",
+			browser: "Chromium", browserVersion: "120", engine: "Blink", deviceType: DeviceSmartphone,
+			hints: ClientHints{UA: `"Not_A Brand";v="8", "Chromium";v="120"`, Mobile: "?1", Platform: `"Android"`},
+		},
+		{
+			name: "edge beats chromium", ua: edgeWindows,
+			os: "Windows", version: "10", platform: "Desktop",
+			browser: "Edge", browserVersion: "120.0.2210.91", engine: "Blink", deviceType: DeviceDesktop, arch: "x86_64",
+			hints: ClientHints{
+				FullVersionList: `"Not?A_Brand";v="99.0.0.0", "Chromium";v="120.0.6099.130", "Microsoft Edge";v="120.0.2210.91"`,
+				Platform:        `"Windows"`,
+				PlatformVersion: `"10.0.0"`,
+			},
+		},
+		{
+			// Platform version 13 and above is Windows 11, which still sends
+			// Windows NT 10.0 in the user agent
+			name: "windows 11", ua: chromeWindows,
+			os: "Windows", version: "11", platform: "Desktop",
+			browser: "Chrome", browserVersion: "120", engine: "Blink", deviceType: DeviceDesktop, arch: "x86_64",
+			hints: ClientHints{UA: `"Google Chrome";v="120"`, Platform: `"Windows"`, PlatformVersion: `"13.0.0"`},
+		},
+		{
+			name: "windows 10", ua: chromeWindows,
+			os: "Windows", version: "10", platform: "Desktop",
+			browser: "Chrome", browserVersion: "120", engine: "Blink", deviceType: DeviceDesktop, arch: "x86_64",
+			hints: ClientHints{UA: `"Google Chrome";v="120"`, Platform: `"Windows"`, PlatformVersion: `"12.0.0"`},
+		},
+		{
+			// Chromium sends ?0 on Android tablets
+			name: "android tablet", ua: reducedAndroid,
+			os: "Android", version: "14", platform: "Tablet",
+			browser: "Chrome", browserVersion: "120", engine: "Blink", deviceType: DeviceTablet,
+			hints: ClientHints{UA: `"Google Chrome";v="120"`, Mobile: "?0", Platform: `"Android"`, PlatformVersion: `"14.0.0"`},
+		},
+		{
+			// The hinted model replaces the one in the user agent
+			name: "model", ua: chromeAndroid,
+			os: "Android", platform: "Tablet",
+			browser: "Chrome", browserVersion: "120", engine: "Blink",
+			vendor: "Samsung", model: "Galaxy Tab S9 Ultra", deviceType: DeviceTablet,
+			hints: ClientHints{UA: `"Google Chrome";v="120"`, Mobile: "?0", Platform: `"Android"`, Model: `"SM-X910"`},
+		},
+		{
+			name: "hints only", ua: "",
+			os: "MacOS", version: "14.2", platform: "Desktop",
+			browser: "Chrome", browserVersion: "120", engine: "Blink", deviceType: DeviceDesktop, arch: "ARM64",
+			hints: ClientHints{UA: `"Google Chrome";v="120"`, Platform: `"macOS"`, PlatformVersion: `"14.2.0"`, Arch: `"arm"`, Bitness: `"64"`},
+		},
+	}
+
+	p := NewDefault(DefaultCacheSize)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := p.ParseWithHints(tt.ua, tt.hints)
+			got := []string{info.OS, str(info.Version), info.Platform, str(info.Browser), str(info.BrowserVersion), str(info.Engine), str(info.DeviceVendor), str(info.DeviceModel), str(info.DeviceType), str(info.Architecture)}
+			want := []string{tt.os, tt.version, tt.platform, tt.browser, tt.browserVersion, tt.engine, tt.vendor, tt.model, tt.deviceType, tt.arch}
+			if strings.Join(got, "|") != strings.Join(want, "|") {
+				t.Errorf("ParseWithHints() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPlatformVersion(t *testing.T) {
+	tests := []struct {
+		platform, version, want string
+	}{
+		{"Windows", "15.0.0", "11"},
+		{"Windows", "13.0.0", "11"},
+		{"Windows", "10.0.0", "10"},
+		{"Windows", "1.0.0", "10"},
+		{"Windows", "0.3.0", "8.1"},
+		{"Windows", "0.1.0", "7"},
+		{"Windows", "", ""},
+		{"Windows", "x", ""},
+		{"Android", "14.0.0", "14"},
+		{"macOS", "14.2.0", "14.2"},
+	}
+	for _, tt := range tests {
+		if got := platformVersion(tt.platform, tt.version); got != tt.want {
+			t.Errorf("platformVersion(%s, %q) = %q, want %q", tt.platform, tt.version, got, tt.want)
+		}
+	}
+}
+
+// uapSnippet is written as in uap-core's regexes.yaml, without any of the
+// local extensions
+const uapSnippet = `
+user_agent_parsers:
+  - regex: '(HeadlessChrome)(?:/(\d+)\.(\d+)\.(\d+)|)'
+  - regex: '(Edg)/(\d+)\.(\d+)\.(\d+)'
+    family_replacement: 'Edge'
+  - regex: '(Chrome)/(\d+)\.(\d+)\.(\d+)'
+  - regex: '(Firefox)/(\d+)\.(\d+)'
+  - regex: '(Version)/(\d+)\.(\d+)(?:\.(\d+)|).*Safari/'
+    family_replacement: 'Safari'
+
+os_parsers:
+  - regex: '(Windows NT 10\.0)'
+    os_replacement: 'Windows'
+    os_v1_replacement: '10'
+  - regex: '(Android)[ \-/](\d+)(?:\.(\d+)|)'
+  - regex: '(Mac OS X) (\d+)[_.](\d+)(?:[_.](\d+)|)'
+
+device_parsers:
+  - regex: '; *(SM-[A-Z0-9]+)'
+    device_replacement: 'Samsung $1'
+    brand_replacement: 'Samsung'
+    model_replacement: '$1'
+  - regex: '(iphone)'
+    regex_flag: 'i'
+    device_replacement: 'iPhone'
+    brand_replacement: 'Apple'
+    model_replacement: 'iPhone'
+`
+
+func TestLoadRulesUAParser(t *testing.T) {
+	rules, err := LoadRules(strings.NewReader(uapSnippet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := New(rules, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ua                                   string
+		os, version, browser, browserVersion string
+		vendor, model                        string
+	}{
+		{ua: edgeWindows, os: "Windows", version: "10", browser: "Edge", browserVersion: "120.0.2210"},
+		{ua: chromeWindows, os: "Windows", version: "10", browser: "Chrome", browserVersion: "120.0.0"},
+		{ua: firefoxWindows, os: "Windows", version: "10", browser: "Firefox", browserVersion: "121.0"},
+		{ua: safariMac, os: "Mac OS X", version: "10.15.7", browser: "Safari", browserVersion: "17.2"},
+		{ua: chromeAndroid, os: "Android", version: "14", browser: "Chrome", browserVersion: "120.0.6099", vendor: "Samsung", model: "SM-S918B"},
+		{ua: safariIPhone, browser: "Safari", browserVersion: "17.2", vendor: "Apple", model: "iPhone"},
+	}
+	for _, tt := range tests {
+		info := p.Parse(tt.ua)
+		if tt.os == "" {
+			tt.os = "Unknown"
+		}
+		got := []string{info.OS, str(info.Version), str(info.Browser), str(info.BrowserVersion), str(info.DeviceVendor), str(info.DeviceModel)}
+		want := []string{tt.os, tt.version, tt.browser, tt.browserVersion, tt.vendor, tt.model}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("Parse(%q) = %q, want %q", tt.ua, got, want)
+		}
+		if info.Engine != nil {
+			t.Errorf("Parse(%q) engine = %q without engine_parsers", tt.ua, *info.Engine)
+		}
+	}
+}
+
+func TestParseCacheHitDoesNotAllocate(t *testing.T) {
+	p := NewDefault(DefaultCacheSize)
+	p.Parse(chromeWindows)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		p.Parse(chromeWindows)
+	})
+	if allocs != 0 {
+		t.Errorf("cached Parse() allocates %v times", allocs)
+	}
+}
+
+func TestParseWithHintsCacheHitDoesNotAllocate(t *testing.T) {
+	p := NewDefault(DefaultCacheSize)
+	hints := ClientHints{UA: `"Chromium";v="120", "Google Chrome";v="120"`, Mobile: "?0", Platform: `"Windows"`}
+	want := p.ParseWithHints(chromeWindows, hints)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		p.ParseWithHints(chromeWindows, hints)
+	})
+	if allocs != 0 {
+		t.Errorf("cached ParseWithHints() allocates %v times", allocs)
+	}
+
+	// The hints are part of the key
+	if got := p.ParseWithHints(chromeWindows, ClientHints{Platform: `"Linux"`}); got.OS == want.OS {
+		t.Errorf("ParseWithHints() with other hints = %s, the cached %s", got.OS, want.OS)
+	}
+}
+
+var benchUAs = []string{chromeWindows, safariMac, edgeWindows, firefoxWindows, safariIPhone, chromeAndroid, chromeIPad, googlebot}
+
+func BenchmarkParse(b *testing.B) {
+	b.Run("hit", func(b *testing.B) {
+		p := NewDefault(DefaultCacheSize)
+		for _, ua := range benchUAs {
+			p.Parse(ua)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p.Parse(benchUAs[i%len(benchUAs)])
+		}
+	})
+
+	b.Run("miss", func(b *testing.B) {
+		p := NewDefault(DefaultCacheSize)
+		// A unique suffix defeats the cache without changing the result
+		uas := make([]string, b.N)
+		for i := range uas {
+			uas[i] = benchUAs[i%len(benchUAs)] + " build/" + strconv.Itoa(i)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p.Parse(uas[i])
+		}
+	})
+
+	b.Run("hints hit", func(b *testing.B) {
+		p := NewDefault(DefaultCacheSize)
+		hints := ClientHints{UA: `"Chromium";v="120", "Google Chrome";v="120"`, Mobile: "?0", Platform: `"Windows"`}
+		for _, ua := range benchUAs {
+			p.ParseWithHints(ua, hints)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p.ParseWithHints(benchUAs[i%len(benchUAs)], hints)
+		}
+	})
+
+	b.Run("uncached", func(b *testing.B) {
+		p := NewDefault(0)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			p.Parse(benchUAs[i%len(benchUAs)])
+		}
+	})
+}
@@ -0,0 +1,194 @@
+package useragent
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed regexes.yaml
+var defaultRules []byte
+
+//go:embed bots.yaml
+var defaultBots []byte
+
+//go:embed devices.yaml
+var defaultModels []byte
+
+// RuleSet is the on-disk rule format. It is compatible with ua-parser's
+// regexes.yaml: a file from uap-core can be loaded as is. The engine, arch,
+// client, app, bot and model sections, and device_type on device rules, are
+// extensions that other ua-parser implementations ignore. Client and app
+// rules use the user_agent_parsers replacement fields.
+type RuleSet struct {
+	UserAgent []Rule        `yaml:"user_agent_parsers"`
+	OS        []Rule        `yaml:"os_parsers"`
+	Device    []Rule        `yaml:"device_parsers"`
+	Client    []Rule        `yaml:"client_parsers"`
+	App       []Rule        `yaml:"app_parsers"`
+	Engine    []Rule        `yaml:"engine_parsers"`
+	Arch      []Rule        `yaml:"arch_parsers"`
+	Bots      []BotRule     `yaml:"bot_parsers"`
+	Models    []DeviceModel `yaml:"device_models"`
+}
+
+// Rule is a single ordered pattern. A replacement, when present, overrides
+// the corresponding capture group and may reference groups as $1..$9.
+type Rule struct {
+	Regex     string `yaml:"regex"`
+	RegexFlag string `yaml:"regex_flag"`
+
+	FamilyReplacement string `yaml:"family_replacement"`
+	V1Replacement     string `yaml:"v1_replacement"`
+	V2Replacement     string `yaml:"v2_replacement"`
+	V3Replacement     string `yaml:"v3_replacement"`
+
+	OSReplacement   string `yaml:"os_replacement"`
+	OSV1Replacement string `yaml:"os_v1_replacement"`
+	OSV2Replacement string `yaml:"os_v2_replacement"`
+	OSV3Replacement string `yaml:"os_v3_replacement"`
+
+	EngineReplacement   string `yaml:"engine_replacement"`
+	EngineV1Replacement string `yaml:"engine_v1_replacement"`
+	EngineV2Replacement string `yaml:"engine_v2_replacement"`
+
+	DeviceReplacement string `yaml:"device_replacement"`
+	BrandReplacement  string `yaml:"brand_replacement"`
+	ModelReplacement  string `yaml:"model_replacement"`
+	DeviceType        string `yaml:"device_type"`
+
+	ArchReplacement string `yaml:"arch_replacement"`
+}
+
+// LoadRules reads a rule file
+func LoadRules(r io.Reader) (*RuleSet, error) {
+	var rules RuleSet
+	if err := yaml.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("failed to parse user-agent rules: %w", err)
+	}
+	return &rules, nil
+}
+
+// LoadRulesFile reads a rule file from disk. Files without a bot_parsers or
+// device_models section, such as uap-core's, get the embedded bot
+// signatures and model database.
+func LoadRulesFile(path string) (*RuleSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rules, err := LoadRules(f)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules.Bots) == 0 {
+		rules.Bots = mustLoad(defaultBots).Bots
+	}
+	if len(rules.Models) == 0 {
+		rules.Models = mustLoad(defaultModels).Models
+	}
+	return rules, nil
+}
+
+// DefaultRules returns the rules embedded in the binary
+func DefaultRules() *RuleSet {
+	rules := mustLoad(defaultRules)
+	rules.Bots = mustLoad(defaultBots).Bots
+	rules.Models = mustLoad(defaultModels).Models
+	return rules
+}
+
+func mustLoad(data []byte) *RuleSet {
+	rules, err := LoadRules(bytes.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// compiledRule is a Rule with its regex compiled and its replacements laid
+// out in capture-group order (name, v1, v2, v3)
+type compiledRule struct {
+	re           *regexp.Regexp
+	replacements [4]string
+}
+
+func compileRules(rules []Rule, replacements func(Rule) [4]string) ([]compiledRule, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		re, err := compileRegex(rule.Regex, rule.RegexFlag)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, compiledRule{re: re, replacements: replacements(rule)})
+	}
+	return compiled, nil
+}
+
+func compileRegex(expr, flag string) (*regexp.Regexp, error) {
+	if flag == "i" {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user-agent rule %q: %w", expr, err)
+	}
+	return re, nil
+}
+
+// match returns the name and up to three version components of the first
+// matching rule
+func match(rules []compiledRule, ua string) (fields [4]string, ok bool) {
+	for i := range rules {
+		rule := &rules[i]
+		loc := rule.re.FindStringSubmatchIndex(ua)
+		if loc == nil {
+			continue
+		}
+
+		for j := range fields {
+			if rule.replacements[j] != "" {
+				fields[j] = expand(rule.replacements[j], ua, loc)
+			} else {
+				fields[j] = group(ua, loc, j+1)
+			}
+		}
+		return fields, true
+	}
+	return fields, false
+}
+
+// group returns capture group n, or "" when it did not participate
+func group(ua string, loc []int, n int) string {
+	if 2*n+1 >= len(loc) || loc[2*n] < 0 {
+		return ""
+	}
+	return ua[loc[2*n]:loc[2*n+1]]
+}
+
+// expand substitutes $1..$9 in a replacement template
+func expand(template, ua string, loc []int) string {
+	if !strings.Contains(template, "$") {
+		return template
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if c == '$' && i+1 < len(template) && template[i+1] >= '1' && template[i+1] <= '9' {
+			b.WriteString(group(ua, loc, int(template[i+1]-'0')))
+			i++
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,557 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/imnitish-dev/ip2location/countries"
+	"github.com/imnitish-dev/ip2location/ip2location"
+	pbv2 "github.com/imnitish-dev/ip2location/proto/v2"
+	"github.com/imnitish-dev/ip2location/timezone"
+)
+
+// ResponseV2 is the /v2 form of Response. Names are snake_case throughout
+// and match the ip2location.v2 proto package. Location merges the
+// providers' results field by field, as the lookup policy prefers them.
+type ResponseV2 struct {
+	IP        string       `json:"ip,omitempty"`
+	Host      string       `json:"host,omitempty"`
+	Location  *LocationV2  `json:"location,omitempty"`
+	Providers *ProvidersV2 `json:"providers,omitempty"`
+	Addresses []AddressV2  `json:"addresses,omitempty"`
+	Device    *DeviceV2    `json:"device,omitempty"`
+	Error     *ErrorV2     `json:"error,omitempty"`
+}
+
+// ProvidersV2 holds the result of each geolocation database
+type ProvidersV2 struct {
+	MaxMind     *LocationV2 `json:"maxmind,omitempty"`
+	IP2Location *LocationV2 `json:"ip2location,omitempty"`
+}
+
+// LocationV2 is one provider's view of where an address is. CityGeoNameID
+// is the GeoNames ID of the city, when known.
+type LocationV2 struct {
+	Country       *CountryV2     `json:"country,omitempty"`
+	Region        *PlaceV2       `json:"region,omitempty"`
+	City          string         `json:"city,omitempty"`
+	CityGeoNameID uint           `json:"city_geoname_id,omitempty"`
+	Coordinates   *CoordinatesV2 `json:"coordinates,omitempty"`
+	TimeZone      *TimeZoneV2    `json:"time_zone,omitempty"`
+	ASN           *ASNV2         `json:"asn,omitempty"`
+	Anonymizer    *AnonymizerV2  `json:"anonymizer,omitempty"`
+}
+
+// ASNV2 is the autonomous system announcing an address, reported when an
+// ASN database is loaded
+type ASNV2 struct {
+	Number       uint   `json:"number"`
+	Organization string `json:"organization,omitempty"`
+}
+
+// AnonymizerV2 flags addresses of VPNs, proxies, hosting providers and Tor
+// exit nodes, reported when an anonymous IP database is loaded. Anonymous
+// is set when any flag but hosting is.
+type AnonymizerV2 struct {
+	Anonymous        bool `json:"anonymous"`
+	VPN              bool `json:"vpn"`
+	Hosting          bool `json:"hosting"`
+	PublicProxy      bool `json:"public_proxy"`
+	ResidentialProxy bool `json:"residential_proxy"`
+	TorExitNode      bool `json:"tor_exit_node"`
+}
+
+// PlaceV2 is a named area. For regions Code is the ISO 3166-2 code, such
+// as US-CA; GeoNameID is set when known.
+type PlaceV2 struct {
+	Code      string `json:"code,omitempty"`
+	Name      string `json:"name,omitempty"`
+	GeoNameID uint   `json:"geoname_id,omitempty"`
+}
+
+// CountryV2 is a country with the metadata of the embedded ISO 3166
+// dataset. Code is the alpha-2 code and Name the dataset's, whatever the
+// provider called it.
+type CountryV2 struct {
+	Code         string       `json:"code,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	GeoNameID    uint         `json:"geoname_id,omitempty"`
+	Alpha3       string       `json:"alpha3,omitempty"`
+	Numeric      string       `json:"numeric,omitempty"`
+	OfficialName string       `json:"official_name,omitempty"`
+	Flag         string       `json:"flag,omitempty"`
+	Continent    *PlaceV2     `json:"continent,omitempty"`
+	Currencies   []string     `json:"currencies,omitempty"`
+	Languages    []LanguageV2 `json:"languages,omitempty"`
+	CallingCodes []string     `json:"calling_codes,omitempty"`
+	TLDs         []string     `json:"tlds,omitempty"`
+	EU           bool         `json:"eu"`
+	EEA          bool         `json:"eea"`
+	GDPR         bool         `json:"gdpr"`
+}
+
+// LanguageV2 is an official language of a country, by ISO 639-3 code
+type LanguageV2 struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// CoordinatesV2 locates an address, with the radius in kilometres in which
+// it is likely to be when the provider reports one
+type CoordinatesV2 struct {
+	Latitude         float64 `json:"latitude"`
+	Longitude        float64 `json:"longitude"`
+	AccuracyRadiusKm uint16  `json:"accuracy_radius_km,omitempty"`
+}
+
+// TimeZoneV2 is the IANA time zone of a location and its state when the
+// response was made
+type TimeZoneV2 struct {
+	Name string `json:"name"`
+	// UTCOffset is the current offset from UTC, such as +05:30
+	UTCOffset string `json:"utc_offset"`
+	DST       bool   `json:"dst"`
+	// LocalTime is the current local time in RFC 3339 format
+	LocalTime string `json:"local_time"`
+}
+
+// AddressV2 is one address a host resolved to
+type AddressV2 struct {
+	IP        string       `json:"ip"`
+	Type      string       `json:"type"`
+	TTL       uint32       `json:"ttl"`
+	Location  *LocationV2  `json:"location,omitempty"`
+	Providers *ProvidersV2 `json:"providers,omitempty"`
+}
+
+// DeviceV2 is the /v2 form of DeviceInfo; parts that could not be
+// determined are left out
+type DeviceV2 struct {
+	Type         string      `json:"type,omitempty"`
+	Platform     string      `json:"platform,omitempty"`
+	Vendor       string      `json:"vendor,omitempty"`
+	Model        string      `json:"model,omitempty"`
+	Architecture string      `json:"architecture,omitempty"`
+	OS           *SoftwareV2 `json:"os,omitempty"`
+	Browser      *SoftwareV2 `json:"browser,omitempty"`
+	Engine       *SoftwareV2 `json:"engine,omitempty"`
+	App          *SoftwareV2 `json:"app,omitempty"`
+	Client       *SoftwareV2 `json:"client,omitempty"`
+	Bot          *BotV2      `json:"bot,omitempty"`
+}
+
+// SoftwareV2 is a named, optionally versioned piece of software
+type SoftwareV2 struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// BotV2 describes a crawler, monitor or HTTP library
+type BotV2 struct {
+	Name     string `json:"name,omitempty"`
+	Category string `json:"category,omitempty"`
+	Verified *bool  `json:"verified,omitempty"`
+}
+
+// ErrorV2 replaces the message of v1 responses
+type ErrorV2 struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// UAQueryV2 is the /v2 form of UAQuery
+type UAQueryV2 struct {
+	UserAgent string            `json:"user_agent"`
+	Hints     map[string]string `json:"hints,omitempty"`
+}
+
+// UAResultV2 is the /v2 form of UAResult
+type UAResultV2 struct {
+	UserAgent string    `json:"user_agent"`
+	Device    *DeviceV2 `json:"device,omitempty"`
+}
+
+// errorCode names the kind of a failed request from its HTTP status.
+// Lookups that found nothing in a batch, which is sent with a 200 status,
+// are reported as lookup_failed.
+func errorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid_request"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusNotAcceptable:
+		return "not_acceptable"
+	case http.StatusBadGateway:
+		return "upstream_error"
+	case http.StatusServiceUnavailable:
+		return "unavailable"
+	case http.StatusGatewayTimeout:
+		return "timeout"
+	case http.StatusInternalServerError:
+		return "internal"
+	}
+	return "lookup_failed"
+}
+
+// toV2 converts a Response to its /v2 form; status is the HTTP status it is
+// sent with
+func toV2(response *Response, status int) ResponseV2 {
+	out := ResponseV2{
+		IP:        response.Ip,
+		Host:      response.Host,
+		Location:  mergedV2(response.Result),
+		Providers: toProvidersV2(response.MaxMind, response.IP2Location),
+	}
+	for _, address := range response.Addresses {
+		out.Addresses = append(out.Addresses, AddressV2{
+			IP:        address.IP,
+			Type:      address.Type,
+			TTL:       address.TTL,
+			Location:  mergedV2(address.Result),
+			Providers: toProvidersV2(address.MaxMind, address.IP2Location),
+		})
+	}
+	if response.DeviceBrowser.OS != "" {
+		out.Device = toDeviceV2(&response.DeviceBrowser)
+	}
+	if response.Status != 0 {
+		status = response.Status
+	}
+	if response.Message != "" {
+		out.Error = &ErrorV2{Code: errorCode(status), Message: response.Message}
+	}
+	return out
+}
+
+// toV2Value converts a Response or a []Response, and a UAResult or a
+// []UAResult, to its /v2 form
+func toV2Value(v interface{}, status int) interface{} {
+	switch v := v.(type) {
+	case Response:
+		return toV2(&v, status)
+	case []Response:
+		out := make([]ResponseV2, len(v))
+		for i := range v {
+			out[i] = toV2(&v[i], status)
+		}
+		return out
+	case UAResult:
+		return UAResultV2{UserAgent: v.UserAgent, Device: toDeviceV2(&v.DeviceBrowser)}
+	case []UAResult:
+		out := make([]UAResultV2, len(v))
+		for i := range v {
+			out[i] = UAResultV2{UserAgent: v[i].UserAgent, Device: toDeviceV2(&v[i].DeviceBrowser)}
+		}
+		return out
+	}
+	return v
+}
+
+// mergedV2 converts the merged location of a lookup, if there was one
+func mergedV2(result *ip2location.Result) *LocationV2 {
+	if result == nil {
+		return nil
+	}
+	return toLocationV2(result.Merged)
+}
+
+func toProvidersV2(maxmind, ip2loc *ip2location.Location) *ProvidersV2 {
+	if maxmind == nil && ip2loc == nil {
+		return nil
+	}
+	return &ProvidersV2{
+		MaxMind:     toLocationV2(maxmind),
+		IP2Location: toLocationV2(ip2loc),
+	}
+}
+
+// toLocationV2 converts a provider result. Providers report 0,0 when they
+// have no coordinates, which is left out, and the time zone is described
+// as of now.
+func toLocationV2(loc *ip2location.Location) *LocationV2 {
+	if loc == nil {
+		return nil
+	}
+
+	out := &LocationV2{
+		Country:       toCountryV2(provided(loc.CountryCode), provided(loc.Country)),
+		Region:        toRegionV2(loc),
+		City:          provided(loc.City),
+		CityGeoNameID: loc.CityGeoNameID,
+	}
+	if out.Country != nil {
+		out.Country.GeoNameID = loc.CountryGeoNameID
+	}
+	if loc.Latitude != 0 || loc.Longitude != 0 {
+		out.Coordinates = &CoordinatesV2{
+			Latitude:         loc.Latitude,
+			Longitude:        loc.Longitude,
+			AccuracyRadiusKm: loc.AccuracyRadius,
+		}
+	}
+	if info, err := timezone.At(loc.TimeZone, time.Now()); err == nil {
+		out.TimeZone = &TimeZoneV2{
+			Name:      info.Name,
+			UTCOffset: info.FormatOffset(),
+			DST:       info.DST,
+			LocalTime: info.LocalTime.Format(time.RFC3339),
+		}
+	}
+	if loc.ASN != nil {
+		out.ASN = &ASNV2{Number: loc.ASN.Number, Organization: loc.ASN.Organization}
+	}
+	if a := loc.Anonymizer; a != nil {
+		out.Anonymizer = &AnonymizerV2{
+			Anonymous:        a.Anonymous,
+			VPN:              a.VPN,
+			Hosting:          a.Hosting,
+			PublicProxy:      a.PublicProxy,
+			ResidentialProxy: a.ResidentialProxy,
+			TorExitNode:      a.TorExitNode,
+		}
+	}
+	return out
+}
+
+// toRegionV2 keeps the provider's region name, falling back to the ISO
+// 3166-2 dataset's when the provider only reported the code
+func toRegionV2(loc *ip2location.Location) *PlaceV2 {
+	region := &PlaceV2{Code: loc.RegionCode, Name: provided(loc.Region), GeoNameID: loc.RegionGeoNameID}
+	if region.Name == "" {
+		if subdivision, ok := countries.LookupSubdivision(loc.RegionCode); ok {
+			region.Name = subdivision.Name
+		}
+	}
+	if region.Code == "" && region.Name == "" {
+		return nil
+	}
+	return region
+}
+
+// provided drops the "-" IP2Location reports for unknown values
+func provided(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
+
+// toDeviceV2 converts parsed device information. An unknown OS is left
+// out, and only bots get a bot object.
+func toDeviceV2(info *DeviceInfo) *DeviceV2 {
+	out := &DeviceV2{
+		Type:         value(info.DeviceType),
+		Platform:     info.Platform,
+		Vendor:       value(info.DeviceVendor),
+		Model:        value(info.DeviceModel),
+		Architecture: value(info.Architecture),
+		Browser:      software(info.Browser, info.BrowserVersion),
+		Engine:       software(info.Engine, info.EngineVersion),
+		App:          software(info.App, info.AppVersion),
+		Client:       software(info.Client, info.ClientVersion),
+	}
+	if info.OS != "" && info.OS != "Unknown" {
+		out.OS = &SoftwareV2{Name: info.OS, Version: value(info.Version)}
+	}
+	if info.IsBot {
+		out.Bot = &BotV2{
+			Name:     value(info.BotName),
+			Category: value(info.BotCategory),
+			Verified: info.BotVerified,
+		}
+	}
+	return out
+}
+
+func software(name, version *string) *SoftwareV2 {
+	if name == nil {
+		return nil
+	}
+	return &SoftwareV2{Name: *name, Version: value(version)}
+}
+
+// expandAddressesV2 turns a host lookup into one response per address
+func expandAddressesV2(response ResponseV2) []ResponseV2 {
+	if len(response.Addresses) == 0 {
+		return []ResponseV2{response}
+	}
+
+	responses := make([]ResponseV2, len(response.Addresses))
+	for i, address := range response.Addresses {
+		responses[i] = ResponseV2{
+			IP:        address.IP,
+			Host:      response.Host,
+			Location:  address.Location,
+			Providers: address.Providers,
+			Device:    response.Device,
+			Error:     response.Error,
+		}
+	}
+	return responses
+}
+
+// GRPCServerV2 implements the ip2location.v2 service
+type GRPCServerV2 struct {
+	pbv2.UnimplementedIP2LocationServiceServer
+	app *App
+}
+
+// LookupIP implements the v2 lookup method. When neither an IP nor a host
+// is given, the caller's address is geolocated.
+func (s *GRPCServerV2) LookupIP(ctx context.Context, req *pbv2.LookupRequest) (*pbv2.LookupResponse, error) {
+	response := &pbv2.LookupResponse{}
+
+	mask, err := protoFieldMask(req.FieldMask, response.ProtoReflect(), apiV2.keep)
+	if err != nil {
+		response.Error = &pbv2.Error{Code: errorCode(http.StatusBadRequest), Message: err.Error()}
+		return response, nil
+	}
+	work := mask.workV2()
+
+	query := req.Host
+	if query == "" {
+		query = req.Ip
+	}
+	if query == "" {
+		query = peerIP(ctx)
+	}
+
+	result := s.app.lookupQuery(ctx, query, work)
+	if work.device {
+		result.DeviceBrowser = s.app.grpcDevice(ctx, req.UserAgent)
+	}
+
+	out := toV2(&result, http.StatusOK)
+	response = toPBResponseV2(&out)
+	pruneProto(response.ProtoReflect(), mask, "")
+	return response, nil
+}
+
+// ParseUserAgent implements the v2 user-agent parsing method
+func (s *GRPCServerV2) ParseUserAgent(ctx context.Context, req *pbv2.ParseUserAgentRequest) (*pbv2.ParseUserAgentResponse, error) {
+	hints := hintsFromMap(req.Hints)
+	if req.UserAgent == "" && hints.IsZero() {
+		return &pbv2.ParseUserAgentResponse{
+			Error: &pbv2.Error{Code: errorCode(http.StatusBadRequest), Message: "user_agent is required"},
+		}, nil
+	}
+
+	info := s.app.ua.ParseWithHints(req.UserAgent, hints)
+	return &pbv2.ParseUserAgentResponse{Device: toPBDeviceV2(toDeviceV2(&info))}, nil
+}
+
+func toPBResponseV2(response *ResponseV2) *pbv2.LookupResponse {
+	out := &pbv2.LookupResponse{
+		Ip:        response.IP,
+		Host:      response.Host,
+		Location:  toPBLocationV2(response.Location),
+		Providers: toPBProvidersV2(response.Providers),
+		Device:    toPBDeviceV2(response.Device),
+	}
+	for _, address := range response.Addresses {
+		out.Addresses = append(out.Addresses, &pbv2.ResolvedAddress{
+			Ip:        address.IP,
+			Type:      address.Type,
+			Ttl:       address.TTL,
+			Location:  toPBLocationV2(address.Location),
+			Providers: toPBProvidersV2(address.Providers),
+		})
+	}
+	if response.Error != nil {
+		out.Error = &pbv2.Error{Code: response.Error.Code, Message: response.Error.Message}
+	}
+	return out
+}
+
+func toPBProvidersV2(providers *ProvidersV2) *pbv2.Providers {
+	if providers == nil {
+		return nil
+	}
+	return &pbv2.Providers{
+		Maxmind:     toPBLocationV2(providers.MaxMind),
+		Ip2Location: toPBLocationV2(providers.IP2Location),
+	}
+}
+
+func toPBLocationV2(loc *LocationV2) *pbv2.Location {
+	if loc == nil {
+		return nil
+	}
+	out := &pbv2.Location{
+		Country:       toPBCountryV2(loc.Country),
+		Region:        toPBPlaceV2(loc.Region),
+		City:          loc.City,
+		CityGeonameId: uint32(loc.CityGeoNameID),
+	}
+	if loc.Coordinates != nil {
+		out.Coordinates = &pbv2.Coordinates{
+			Latitude:         loc.Coordinates.Latitude,
+			Longitude:        loc.Coordinates.Longitude,
+			AccuracyRadiusKm: uint32(loc.Coordinates.AccuracyRadiusKm),
+		}
+	}
+	if loc.TimeZone != nil {
+		out.TimeZone = &pbv2.TimeZone{
+			Name:      loc.TimeZone.Name,
+			UtcOffset: loc.TimeZone.UTCOffset,
+			Dst:       loc.TimeZone.DST,
+			LocalTime: loc.TimeZone.LocalTime,
+		}
+	}
+	if loc.ASN != nil {
+		out.Asn = &pbv2.ASN{Number: uint32(loc.ASN.Number), Organization: loc.ASN.Organization}
+	}
+	if a := loc.Anonymizer; a != nil {
+		out.Anonymizer = &pbv2.Anonymizer{
+			Anonymous:        a.Anonymous,
+			Vpn:              a.VPN,
+			Hosting:          a.Hosting,
+			PublicProxy:      a.PublicProxy,
+			ResidentialProxy: a.ResidentialProxy,
+			TorExitNode:      a.TorExitNode,
+		}
+	}
+	return out
+}
+
+func toPBPlaceV2(p *PlaceV2) *pbv2.Place {
+	if p == nil {
+		return nil
+	}
+	return &pbv2.Place{Code: p.Code, Name: p.Name, GeonameId: uint32(p.GeoNameID)}
+}
+
+func toPBDeviceV2(device *DeviceV2) *pbv2.Device {
+	if device == nil {
+		return nil
+	}
+	out := &pbv2.Device{
+		Type:         device.Type,
+		Platform:     device.Platform,
+		Vendor:       device.Vendor,
+		Model:        device.Model,
+		Architecture: device.Architecture,
+		Os:           toPBSoftwareV2(device.OS),
+		Browser:      toPBSoftwareV2(device.Browser),
+		Engine:       toPBSoftwareV2(device.Engine),
+		App:          toPBSoftwareV2(device.App),
+		Client:       toPBSoftwareV2(device.Client),
+	}
+	if device.Bot != nil {
+		out.Bot = &pbv2.Bot{
+			Name:     device.Bot.Name,
+			Category: device.Bot.Category,
+			Verified: device.Bot.Verified,
+		}
+	}
+	return out
+}
+
+func toPBSoftwareV2(s *SoftwareV2) *pbv2.Software {
+	if s == nil {
+		return nil
+	}
+	return &pbv2.Software{Name: s.Name, Version: s.Version}
+}
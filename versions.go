@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// apiVersion describes one version of the REST API. Handlers are shared;
+// render converts their v1 results to the version of the route.
+type apiVersion struct {
+	name string
+	// response is the type ?fields= paths are checked against
+	response reflect.Type
+	// keep is the field that carries errors, kept in every field mask
+	keep string
+}
+
+var (
+	// apiV1 is the original response shape, frozen
+	apiV1 = &apiVersion{name: "v1", response: reflect.TypeOf(Response{}), keep: "message"}
+	// apiV2 has the models of v2.go
+	apiV2 = &apiVersion{name: "v2", response: reflect.TypeOf(ResponseV2{}), keep: "error"}
+)
+
+// The unversioned routes are aliases of /v1 that announce their deprecation
+// (RFC 9745) and removal (RFC 8594). These are the dates announced unless
+// UNVERSIONED_DEPRECATION and UNVERSIONED_SUNSET say otherwise.
+var (
+	defaultUnversionedDeprecation = time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+	defaultUnversionedSunset      = time.Date(2027, time.May, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// useVersion selects the API version of the routes it is attached to
+func useVersion(version *apiVersion) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("api", version)
+		return c.Next()
+	}
+}
+
+// versionOf returns the API version of the request; unversioned routes are
+// v1
+func versionOf(c *fiber.Ctx) *apiVersion {
+	if version, ok := c.Locals("api").(*apiVersion); ok {
+		return version
+	}
+	return apiV1
+}
+
+// deprecated marks a response from an unversioned route and links to its
+// /v1 successor
+func (a *App) deprecated(c *fiber.Ctx) error {
+	successor := strings.TrimSuffix("/v1"+c.Path(), "/")
+	c.Set("Deprecation", "@"+strconv.FormatInt(a.unversionedDeprecation.Unix(), 10))
+	c.Set("Sunset", a.unversionedSunset.UTC().Format(http.TimeFormat))
+	c.Append(fiber.HeaderLink, "<"+successor+`>; rel="successor-version"`)
+	return c.Next()
+}
+
+// errorBody is the body of a failed request in the version of c, which
+// must have its status set
+func errorBody(c *fiber.Ctx, message string) interface{} {
+	if versionOf(c) == apiV2 {
+		return ResponseV2{Error: &ErrorV2{
+			Code:    errorCode(c.Response().StatusCode()),
+			Message: message,
+		}}
+	}
+	return Response{Message: message}
+}
+
+// writeError sends message with status as a JSON error body
+func writeError(c *fiber.Ctx, status int, message string) error {
+	c.Status(status)
+	return c.JSON(errorBody(c, message))
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestDeprecatedHeaders(t *testing.T) {
+	a := &App{
+		unversionedDeprecation: time.Date(2030, time.January, 2, 0, 0, 0, 0, time.UTC),
+		unversionedSunset:      time.Date(2031, time.March, 4, 12, 0, 0, 0, time.FixedZone("CET", 3600)),
+	}
+	app := fiber.New()
+	app.Get("/ua", a.deprecated, func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+	res, err := app.Test(httptest.NewRequest("GET", "/ua", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"Deprecation": "@1893542400",
+		"Sunset":      "Tue, 04 Mar 2031 11:00:00 GMT",
+		"Link":        `</v1/ua>; rel="successor-version"`,
+	}
+	for header, value := range want {
+		if got := res.Header.Get(header); got != value {
+			t.Errorf("%s = %q, want %q", header, got, value)
+		}
+	}
+}
+
+func TestGetEnvTime(t *testing.T) {
+	fallback := time.Date(2027, time.May, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"":                          fallback,
+		"soon":                      fallback,
+		"2028-02-29":                time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"2028-02-29T10:00:00+02:00": time.Date(2028, time.February, 29, 8, 0, 0, 0, time.UTC),
+	}
+	for value, want := range tests {
+		t.Setenv("TEST_DATE", value)
+		if got := getEnvTime("TEST_DATE", fallback); !got.Equal(want) {
+			t.Errorf("getEnvTime(%q) = %v, want %v", value, got, want)
+		}
+	}
+}